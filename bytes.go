@@ -0,0 +1,123 @@
+package converter
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+// ByteEncoding is how bytes are stored in a string when converting between them, this is set for a field with a
+// struct tag, e.g.:
+//
+//	Checksum string `convert:"encoding=hex"`
+//
+// or for a whole conversion using WithByteEncoding. The struct tag of the field holding the string is used before the
+// struct tag of the other field, so each version of a struct is able to declare its own encoding. Runes are always
+// converted to and from strings as they are.
+type ByteEncoding string
+
+const (
+	// ByteEncodingRaw stores the bytes in the string as they are, this is the default
+	ByteEncodingRaw ByteEncoding = ""
+	// ByteEncodingHex stores the bytes as a hexadecimal string
+	ByteEncodingHex ByteEncoding = "hex"
+	// ByteEncodingBase64 stores the bytes as a standard, padded, base64 string
+	ByteEncodingBase64 ByteEncoding = "base64"
+)
+
+// isByteString indicates values are converted between a string and a slice of bytes or runes
+func isByteString(fromType, toType reflect.Type) bool {
+	return isString(fromType) && isByteSlice(toType) || isByteSlice(fromType) && isString(toType)
+}
+
+func isByteSlice(typ reflect.Type) bool {
+	return isSlice(typ) && (isByte(typ.Elem()) || isRune(typ.Elem()))
+}
+
+func isByte(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Uint8
+}
+
+func isRune(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Int32
+}
+
+// byteEncodings are the byte encodings set by the struct tags of the source and target fields being converted
+type byteEncodings struct {
+	from ByteEncoding
+	to   ByteEncoding
+}
+
+// of returns the encoding of a string being decoded from the source or encoded to the target, which is the encoding of
+// the field holding the string, otherwise the other field, otherwise the fallback
+func (e byteEncodings) of(decoding bool, fallback ByteEncoding) ByteEncoding {
+	first, second := e.to, e.from
+	if decoding {
+		first, second = e.from, e.to
+	}
+	switch {
+	case first != "":
+		return first
+	case second != "":
+		return second
+	}
+	return fallback
+}
+
+// convertByteString converts between a string and a slice of bytes or runes, see isByteString
+func (c *conversion) convertByteString(value reflect.Value, targetType reflect.Type) (reflect.Value, error) {
+	typ := value.Type()
+	out := reflect.New(targetType).Elem()
+	switch {
+	case isString(typ) && isRune(targetType.Elem()):
+		runes := []rune(value.String())
+		out.Set(reflect.MakeSlice(targetType, len(runes), len(runes)))
+		for i, r := range runes {
+			out.Index(i).SetInt(int64(r))
+		}
+	case isString(typ):
+		b, err := decodeBytes(c.fieldEncodings.of(true, c.byteEncoding), value.String())
+		if err != nil {
+			return nilValue, err
+		}
+		out.SetBytes(b)
+	case isRune(typ.Elem()):
+		runes := make([]rune, value.Len())
+		for i := range runes {
+			runes[i] = rune(value.Index(i).Int())
+		}
+		out.SetString(string(runes))
+	default:
+		str, err := encodeBytes(c.fieldEncodings.of(false, c.byteEncoding), value.Bytes())
+		if err != nil {
+			return nilValue, err
+		}
+		out.SetString(str)
+	}
+	return out, nil
+}
+
+func encodeBytes(encoding ByteEncoding, b []byte) (string, error) {
+	switch encoding {
+	case ByteEncodingRaw:
+		return string(b), nil
+	case ByteEncodingHex:
+		return hex.EncodeToString(b), nil
+	case ByteEncodingBase64:
+		return base64.StdEncoding.EncodeToString(b), nil
+	}
+	return "", fmt.Errorf("unknown byte encoding: %q", encoding)
+}
+
+func decodeBytes(encoding ByteEncoding, str string) ([]byte, error) {
+	switch encoding {
+	case ByteEncodingRaw:
+		return []byte(str), nil
+	case ByteEncodingHex:
+		return hex.DecodeString(str)
+	case ByteEncodingBase64:
+		return base64.StdEncoding.DecodeString(str)
+	}
+	return nil, fmt.Errorf("unknown byte encoding: %q", encoding)
+}
@@ -0,0 +1,59 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConvertByteString(t *testing.T) {
+	type v1 struct {
+		Name     string
+		Checksum string
+		Content  string
+		Label    []rune
+	}
+	type v2 struct {
+		Name     []byte
+		Checksum []byte `convert:"encoding=hex"`
+		Content  []byte `convert:"encoding=base64"`
+		Label    string
+	}
+	type v3 struct {
+		Name     string
+		Checksum string `convert:"encoding=base64"`
+		Content  []byte
+		Label    []byte
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+
+	from := v1{Name: "naïve", Checksum: "0a1b", Content: "aGVsbG8=", Label: []rune("ラベル")}
+	got := v2{}
+	require.NoError(t, chain.Convert(from, &got))
+	require.Equal(t, v2{Name: []byte("naïve"), Checksum: []byte{0x0a, 0x1b}, Content: []byte("hello"), Label: "ラベル"}, got)
+
+	back := v1{}
+	require.NoError(t, chain.Convert(got, &back))
+	require.Equal(t, from, back)
+
+	// the encoding of the field holding the string is used
+	got3 := v3{}
+	require.NoError(t, chain.Convert(got, &got3))
+	require.Equal(t, v3{Name: "naïve", Checksum: "Chs=", Content: []byte("hello"), Label: []byte("ラベル")}, got3)
+	got = v2{}
+	require.NoError(t, chain.Convert(got3, &got))
+	require.Equal(t, []byte{0x0a, 0x1b}, got.Checksum)
+
+	// the encoding may be set for fields without one
+	got3 = v3{}
+	require.NoError(t, chain.Convert(v2{Label: "0a"}, &got3, WithByteEncoding(ByteEncodingHex)))
+	require.Equal(t, []byte{0x0a}, got3.Label)
+
+	err := chain.Convert(v1{Checksum: "xyz", Content: "!"}, &v2{})
+	require.ErrorContains(t, err, "converting Checksum from string to []uint8 (step 0): encoding/hex: invalid byte")
+	require.ErrorContains(t, err, "converting Content from string to []uint8 (step 0): illegal base64 data")
+
+	err = chain.Convert(v1{Name: "name"}, &v2{}, WithByteEncoding("base32"))
+	require.ErrorContains(t, err, `unknown byte encoding: "base32"`)
+}
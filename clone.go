@@ -1,5 +1,7 @@
 package converter
 
-func Clone(from, to any) error {
-	return NewFuncChain().AllowImplicit().Convert(from, to)
+// Clone converts from to to implicitly, without any registered conversions. Zero values are treated as absent, the
+// same as by Convert, unless WithZeroValues is provided.
+func Clone(from, to any, opts ...ConvertOption) error {
+	return NewFuncChain().AllowImplicit().Convert(from, to, opts...)
 }
@@ -19,3 +19,46 @@ func Test_Clone(t *testing.T) {
 
 	require.Equal(t, original, got)
 }
+
+func Test_CloneCycles(t *testing.T) {
+	root := &nodeV1{Name: "root"}
+	child := &nodeV1{Name: "child", Parent: root}
+	root.Children = []*nodeV1{child, child}
+
+	got := &nodeV1{}
+	err := Clone(root, got)
+	require.NoError(t, err)
+
+	require.NotSame(t, root, got)
+	require.NotSame(t, child, got.Children[0])
+	require.Same(t, got.Children[0], got.Children[1])
+	require.Same(t, got, got.Children[0].Parent)
+	require.Equal(t, "child", got.Children[0].Name)
+}
+
+func Test_CloneZeroValues(t *testing.T) {
+	type inner struct {
+		Value int
+	}
+	type t1 struct {
+		Enabled *bool
+		Inner   *inner
+		Missing *inner
+	}
+
+	original := t1{Enabled: new(bool), Inner: &inner{}}
+
+	// by default, zero values are treated as absent
+	got := t1{}
+	err := Clone(original, &got)
+	require.NoError(t, err)
+	require.Equal(t, t1{}, got)
+
+	got = t1{}
+	err = Clone(original, &got, WithZeroValues())
+	require.NoError(t, err)
+
+	require.Equal(t, original, got)
+	require.NotSame(t, original.Enabled, got.Enabled)
+	require.NotSame(t, original.Inner, got.Inner)
+}
@@ -0,0 +1,191 @@
+// Command convertgen generates Go code performing the same conversions as a converter.FuncChain, without reflection.
+// It is intended to be used with go:generate, from the package declaring the chain and types, e.g.:
+//
+//	//go:generate go run github.com/anchore/go-struct-converter/cmd/convertgen -chain Chain -pairs V1:V3,V3:V1
+//
+// The chain is referenced by an expression in the package, such as an exported variable or a function call, and
+// is loaded by building a temporary program which imports the package. The generated file has a build constraint so
+// it is excluded from this program, which allows regenerating code that no longer compiles. Other files in the
+// package calling the generated functions must have the same constraint, otherwise the program fails to build.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// buildTag is set when building the program which loads the chain, the generated code is excluded by this tag
+const buildTag = "convertgen"
+
+type config struct {
+	chain  string
+	pairs  []pair
+	output string
+}
+
+type pair struct {
+	From string
+	To   string
+	Name string
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "convertgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	cfg, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	importPath, pkgName, err := currentPackage()
+	if err != nil {
+		return err
+	}
+	if pkgName == "main" {
+		return fmt.Errorf("unable to generate code in a main package")
+	}
+
+	program, err := programSource(importPath, pkgName, cfg)
+	if err != nil {
+		return err
+	}
+
+	// the program must be within the module to import the package, directories starting with a . are ignored by
+	// package patterns such as ./...
+	dir, err := os.MkdirTemp(".", ".convertgen-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), program, 0o600); err != nil {
+		return err
+	}
+
+	output, err := filepath.Abs(cfg.output)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "run", "-tags", buildTag, "./"+filepath.ToSlash(dir), output)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func parseArgs(args []string) (config, error) {
+	var cfg config
+	var pairs string
+
+	flags := flag.NewFlagSet("convertgen", flag.ContinueOnError)
+	flags.StringVar(&cfg.chain, "chain", "", "expression in the package providing the FuncChain, e.g. Chain or NewChain()")
+	flags.StringVar(&pairs, "pairs", "", "comma-separated types to generate conversions for, in the form From:To or From:To=FuncName")
+	flags.StringVar(&cfg.output, "o", "zz_generated_convert.go", "output file")
+	if err := flags.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	if cfg.chain == "" {
+		return cfg, fmt.Errorf("-chain is required")
+	}
+
+	var err error
+	cfg.pairs, err = parsePairs(pairs)
+	return cfg, err
+}
+
+// parsePairs parses a list of pairs in the form: From:To,From:To=FuncName
+func parsePairs(value string) ([]pair, error) {
+	var out []pair
+	for _, p := range strings.Split(value, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		types, name, _ := strings.Cut(p, "=")
+		from, to, ok := strings.Cut(types, ":")
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid pair %q, expected the form From:To", p)
+		}
+		out = append(out, pair{From: from, To: to, Name: name})
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("-pairs is required")
+	}
+	return out, nil
+}
+
+// currentPackage returns the import path and name of the package in the current directory
+func currentPackage() (string, string, error) {
+	out, err := exec.Command("go", "list", "-tags", buildTag, "-f", "{{.ImportPath}} {{.Name}}", ".").Output()
+	if err != nil {
+		return "", "", fmt.Errorf("unable to determine the current package: %w", err)
+	}
+	importPath, name, ok := strings.Cut(strings.TrimSpace(string(out)), " ")
+	if !ok {
+		return "", "", fmt.Errorf("unable to determine the current package from: %s", out)
+	}
+	return importPath, name, nil
+}
+
+// programSource returns the source of the program which loads the chain and writes the generated code
+func programSource(importPath, pkgName string, cfg config) ([]byte, error) {
+	var buf bytes.Buffer
+	err := programTemplate.Execute(&buf, map[string]any{
+		"ImportPath": importPath,
+		"Package":    pkgName,
+		"Chain":      cfg.chain,
+		"Pairs":      cfg.pairs,
+	})
+	return buf.Bytes(), err
+}
+
+var programTemplate = template.Must(template.New("program").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	converter "github.com/anchore/go-struct-converter"
+	target {{ printf "%q" .ImportPath }}
+)
+
+func main() {
+	pairs := []converter.TypePair{
+{{- range .Pairs }}
+		named(converter.Pair[target.{{ .From }}, target.{{ .To }}](), {{ printf "%q" .Name }}),
+{{- end }}
+	}
+
+	src, err := converter.Generate(target.{{ .Chain }}, converter.GenerateOptions{
+		Package:     {{ printf "%q" .Package }},
+		PackagePath: {{ printf "%q" .ImportPath }},
+		Pairs:       pairs,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(os.Args[1], src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func named(pair converter.TypePair, name string) converter.TypePair {
+	pair.Name = name
+	return pair
+}
+`))
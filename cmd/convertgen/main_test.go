@@ -0,0 +1,69 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parsePairs(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []pair
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name:  "single",
+			value: "V1:V2",
+			expected: []pair{
+				{From: "V1", To: "V2"},
+			},
+		},
+		{
+			name:  "multiple with names",
+			value: "V1:V3, V3:V1=Downgrade,",
+			expected: []pair{
+				{From: "V1", To: "V3"},
+				{From: "V3", To: "V1", Name: "Downgrade"},
+			},
+		},
+		{
+			name:    "missing target",
+			value:   "V1",
+			wantErr: require.Error,
+		},
+		{
+			name:    "empty",
+			value:   "",
+			wantErr: require.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+			got, err := parsePairs(test.value)
+			test.wantErr(t, err)
+			require.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func Test_programSource(t *testing.T) {
+	cfg, err := parseArgs([]string{"-chain", "NewChain()", "-pairs", "V1:V2,V2:V1=Downgrade"})
+	require.NoError(t, err)
+	require.Equal(t, "zz_generated_convert.go", cfg.output)
+
+	src, err := programSource("example.com/pkg/v2", "v2", cfg)
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "main.go", src, 0)
+	require.NoError(t, err)
+	require.Contains(t, string(src), `target.NewChain()`)
+	require.Contains(t, string(src), `named(converter.Pair[target.V2, target.V1](), "Downgrade")`)
+}
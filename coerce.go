@@ -0,0 +1,319 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Primitive is any type with a primitive kind, these are able to be converted between each other
+type Primitive interface {
+	~string | ~bool |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Coerce converts a primitive value to another primitive type, the same as Convert does, using these rules:
+//
+//   - to a string: values are formatted by strconv, using the shortest representation for floats, e.g. 1.5 or true
+//   - from a string to a bool: the spellings accepted by strconv.ParseBool, e.g. 1, t, true, FALSE
+//   - from a string to a number: a decimal number, integers may be written as floats, e.g. 12, -3.0 or 1e3
+//   - from a bool to a number: true is 1 and false is 0
+//   - from a number to a bool: 0 is false and 1 is true, any other number overflows to true
+//   - from a float to an integer: a fractional part loses precision and is truncated toward zero, and NaN is not able
+//     to be converted at all
+//   - to a float: numbers the float is not able to represent exactly lose precision and are rounded to the nearest
+//     float; floats are compared and converted by their shortest decimal representation, so 0.1 converts between
+//     float32 and float64 exactly
+//   - numbers outside the range of the type overflow: integers wrap, floats saturate at the minimum or maximum integer
+//     and become infinite when converted to a smaller float
+//
+// Numbers which overflow or lose precision result in an ErrOverflow or ErrPrecisionLoss error, along with the converted
+// value, any other error results in the zero value. This is used by code generated by convertgen.
+func Coerce[To, From Primitive](from From) (To, error) {
+	var out To
+	value := reflect.ValueOf(from)
+	converted, err := getPrimitiveCoercer(value.Type(), reflect.TypeFor[To]())(value)
+	if converted.IsValid() {
+		out = converted.Interface().(To)
+	}
+	return out, err
+}
+
+// coerceFunc sets the to value from a primitive value of any kind
+type coerceFunc func(from, to reflect.Value) error
+
+// newPrimitiveCoercer returns a function converting primitive values to the type, see Coerce for the rules
+func newPrimitiveCoercer(toType reflect.Type) primitiveCoercer {
+	var coerce coerceFunc
+	switch {
+	case isString(toType):
+		coerce = coerceToString
+	case isBool(toType):
+		coerce = coerceToBool
+	case isInt(toType):
+		coerce = coerceToInt
+	case isUint(toType):
+		coerce = coerceToUint
+	case isFloat(toType):
+		coerce = coerceToFloat
+	default:
+		coerce = func(from, _ reflect.Value) error {
+			return fmt.Errorf("unable to convert from: %v to %v", from.Interface(), nameOf(toType))
+		}
+	}
+
+	return func(value reflect.Value) (reflect.Value, error) {
+		out := reflect.New(toType).Elem()
+		err := coerce(value, out)
+		if err != nil && !isLossy(err) {
+			return nilValue, err
+		}
+		return out, err
+	}
+}
+
+func coerceToString(from, to reflect.Value) error {
+	to.SetString(formatPrimitive(from))
+	return nil
+}
+
+func coerceToBool(from, to reflect.Value) error {
+	switch typ := from.Type(); {
+	case isString(typ):
+		b, err := strconv.ParseBool(from.String())
+		if err != nil {
+			return err
+		}
+		to.SetBool(b)
+	case isBool(typ):
+		to.SetBool(from.Bool())
+	default:
+		f := numberAsFloat(from)
+		to.SetBool(f != 0)
+		if f != 0 && f != 1 {
+			return overflowError(formatPrimitive(from), to.Type())
+		}
+	}
+	return nil
+}
+
+func coerceToInt(from, to reflect.Value) error {
+	switch typ := from.Type(); {
+	case isString(typ):
+		n, err := parseNumber(from.String())
+		if err != nil {
+			return err
+		}
+		return coerceToInt(n, to)
+	case isBool(typ):
+		to.SetInt(int64(boolAsFloat(from.Bool())))
+	case isInt(typ):
+		i := from.Int()
+		to.SetInt(i)
+		if to.OverflowInt(i) {
+			return overflowError(formatPrimitive(from), to.Type())
+		}
+	case isUint(typ):
+		u := from.Uint()
+		to.SetInt(int64(u))
+		if u > math.MaxInt64 || to.OverflowInt(int64(u)) {
+			return overflowError(formatPrimitive(from), to.Type())
+		}
+	case isFloat(typ):
+		bits := to.Type().Bits()
+		f := from.Float()
+		if math.IsNaN(f) {
+			return notANumberError(to.Type())
+		}
+		t := math.Trunc(f)
+		switch {
+		case t >= math.Ldexp(1, bits-1):
+			to.SetInt(math.MaxInt64 >> (64 - bits))
+		case t < -math.Ldexp(1, bits-1):
+			to.SetInt(math.MinInt64 >> (64 - bits))
+		default:
+			to.SetInt(int64(t))
+			return truncationError(from, to, t)
+		}
+		return overflowError(formatPrimitive(from), to.Type())
+	}
+	return nil
+}
+
+func coerceToUint(from, to reflect.Value) error {
+	switch typ := from.Type(); {
+	case isString(typ):
+		n, err := parseNumber(from.String())
+		if err != nil {
+			return err
+		}
+		return coerceToUint(n, to)
+	case isBool(typ):
+		to.SetUint(uint64(boolAsFloat(from.Bool())))
+	case isInt(typ):
+		i := from.Int()
+		to.SetUint(uint64(i))
+		if i < 0 || to.OverflowUint(uint64(i)) {
+			return overflowError(formatPrimitive(from), to.Type())
+		}
+	case isUint(typ):
+		u := from.Uint()
+		to.SetUint(u)
+		if to.OverflowUint(u) {
+			return overflowError(formatPrimitive(from), to.Type())
+		}
+	case isFloat(typ):
+		bits := to.Type().Bits()
+		f := from.Float()
+		if math.IsNaN(f) {
+			return notANumberError(to.Type())
+		}
+		t := math.Trunc(f)
+		switch {
+		case t >= math.Ldexp(1, bits):
+			to.SetUint(math.MaxUint64 >> (64 - bits))
+		case t < 0:
+			to.SetUint(0)
+		default:
+			to.SetUint(uint64(t))
+			return truncationError(from, to, t)
+		}
+		return overflowError(formatPrimitive(from), to.Type())
+	}
+	return nil
+}
+
+// truncationError returns an error if the float value had a fractional part which was truncated
+func truncationError(from, to reflect.Value, truncated float64) error {
+	if truncated != from.Float() {
+		return precisionError(formatPrimitive(from), to.Type())
+	}
+	return nil
+}
+
+func coerceToFloat(from, to reflect.Value) error {
+	var f float64
+	// the exact decimal representation of the source value
+	exact := formatPrimitive(from)
+	switch typ := from.Type(); {
+	case isString(typ):
+		var err error
+		f, err = strconv.ParseFloat(from.String(), 64)
+		switch {
+		case errors.Is(err, strconv.ErrRange):
+			to.SetFloat(f)
+			return overflowError(exact, to.Type())
+		case err != nil:
+			return err
+		}
+	case isBool(typ):
+		f = boolAsFloat(from.Bool())
+		exact = strconv.FormatFloat(f, 'g', -1, 64)
+	case isFloat(typ):
+		// the decimal representation is converted, rather than the binary value, so a float32 of 0.1 is 0.1 as a float64
+		f, _ = strconv.ParseFloat(exact, 64)
+	default:
+		f = numberAsFloat(from)
+	}
+
+	to.SetFloat(f)
+	converted := to.Float()
+	switch {
+	case math.IsNaN(f) || math.IsInf(f, 0):
+		return nil
+	case math.IsInf(converted, 0):
+		return overflowError(exact, to.Type())
+	case !sameNumber(exact, strconv.FormatFloat(converted, 'g', -1, to.Type().Bits())):
+		return precisionError(exact, to.Type())
+	}
+	return nil
+}
+
+// parseNumber parses a decimal number as an int64, uint64 or float64, using the first able to represent the number
+func parseNumber(str string) (reflect.Value, error) {
+	i, err := strconv.ParseInt(str, 10, 64)
+	if err == nil {
+		return reflect.ValueOf(i), nil
+	}
+	if u, uintErr := strconv.ParseUint(str, 10, 64); uintErr == nil {
+		return reflect.ValueOf(u), nil
+	}
+	f, floatErr := strconv.ParseFloat(str, 64)
+	if floatErr != nil && !errors.Is(floatErr, strconv.ErrRange) {
+		// report the number as not being an integer rather than a float, which is more likely expected
+		return nilValue, err
+	}
+	return reflect.ValueOf(f), nil
+}
+
+// numberAsFloat returns the value of an int, uint or float as a float64, which may not be exact
+func numberAsFloat(value reflect.Value) float64 {
+	switch typ := value.Type(); {
+	case isInt(typ):
+		return float64(value.Int())
+	case isUint(typ):
+		return float64(value.Uint())
+	case isFloat(typ):
+		return value.Float()
+	}
+	return 0
+}
+
+func boolAsFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func overflowError(str string, toType reflect.Type) error {
+	return fmt.Errorf("%w: %s overflows %s", ErrOverflow, str, nameOf(toType))
+}
+
+func precisionError(str string, toType reflect.Type) error {
+	return fmt.Errorf("%w: %s is not able to be represented exactly by %s", ErrPrecisionLoss, str, nameOf(toType))
+}
+
+// notANumberError is returned for NaN converted to an integer, which has no value to be approximated by
+func notANumberError(toType reflect.Type) error {
+	return fmt.Errorf("NaN is not able to be converted to %s", nameOf(toType))
+}
+
+// isLossy returns true for errors where a value was converted, but not exactly
+func isLossy(err error) bool {
+	return errors.Is(err, ErrOverflow) || errors.Is(err, ErrPrecisionLoss)
+}
+
+// sameNumber returns true if the decimal numbers represented by the strings are exactly equal
+func sameNumber(a, b string) bool {
+	if a == b {
+		return true
+	}
+	x, okA := new(big.Rat).SetString(a)
+	y, okB := new(big.Rat).SetString(b)
+	return okA && okB && x.Cmp(y) == 0
+}
+
+// formatPrimitive returns the string representation of a primitive value, the same as fmt.Sprintf("%v") but without
+// the overhead of formatting an arbitrary value
+func formatPrimitive(value reflect.Value) string {
+	typ := value.Type()
+	switch {
+	case isString(typ):
+		return value.String()
+	case isBool(typ):
+		return strconv.FormatBool(value.Bool())
+	case isInt(typ):
+		return strconv.FormatInt(value.Int(), 10)
+	case isUint(typ):
+		return strconv.FormatUint(value.Uint(), 10)
+	case isFloat(typ):
+		return strconv.FormatFloat(value.Float(), 'g', -1, typ.Bits())
+	}
+	return ""
+}
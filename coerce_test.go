@@ -0,0 +1,160 @@
+package converter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Coerce(t *testing.T) {
+	type named string
+
+	tests := []struct {
+		from     any
+		to       reflect.Type
+		expected any
+		err      error
+	}{
+		// to string
+		{from: 12, to: reflect.TypeFor[string](), expected: "12"},
+		{from: uint8(255), to: reflect.TypeFor[string](), expected: "255"},
+		{from: 1.5, to: reflect.TypeFor[string](), expected: "1.5"},
+		{from: float32(0.1), to: reflect.TypeFor[string](), expected: "0.1"},
+		{from: true, to: reflect.TypeFor[string](), expected: "true"},
+		{from: 7, to: reflect.TypeFor[named](), expected: named("7")},
+
+		// to bool
+		{from: "true", to: reflect.TypeFor[bool](), expected: true},
+		{from: "T", to: reflect.TypeFor[bool](), expected: true},
+		{from: "0", to: reflect.TypeFor[bool](), expected: false},
+		{from: "FALSE", to: reflect.TypeFor[bool](), expected: false},
+		{from: "yes", to: reflect.TypeFor[bool](), err: strconv.ErrSyntax},
+		{from: 1, to: reflect.TypeFor[bool](), expected: true},
+		{from: uint(0), to: reflect.TypeFor[bool](), expected: false},
+		{from: 1.0, to: reflect.TypeFor[bool](), expected: true},
+		{from: 2, to: reflect.TypeFor[bool](), expected: true, err: ErrOverflow},
+		{from: 0.5, to: reflect.TypeFor[bool](), expected: true, err: ErrOverflow},
+
+		// to signed integers
+		{from: "12", to: reflect.TypeFor[int](), expected: 12},
+		{from: "-3.0", to: reflect.TypeFor[int](), expected: -3},
+		{from: "1e3", to: reflect.TypeFor[int16](), expected: int16(1000)},
+		{from: "3.5", to: reflect.TypeFor[int](), expected: 3, err: ErrPrecisionLoss},
+		{from: "70000", to: reflect.TypeFor[int16](), expected: int16(4464), err: ErrOverflow},
+		{from: "1e400", to: reflect.TypeFor[int](), expected: math.MaxInt, err: ErrOverflow},
+		{from: "abc", to: reflect.TypeFor[int](), err: strconv.ErrSyntax},
+		{from: true, to: reflect.TypeFor[int8](), expected: int8(1)},
+		{from: int64(127), to: reflect.TypeFor[int8](), expected: int8(127)},
+		{from: int64(128), to: reflect.TypeFor[int8](), expected: int8(-128), err: ErrOverflow},
+		{from: int64(-129), to: reflect.TypeFor[int8](), expected: int8(127), err: ErrOverflow},
+		{from: uint(42), to: reflect.TypeFor[int](), expected: 42},
+		{from: uint64(math.MaxUint64), to: reflect.TypeFor[int64](), expected: int64(-1), err: ErrOverflow},
+		{from: 3.0, to: reflect.TypeFor[int](), expected: 3},
+		{from: -2.7, to: reflect.TypeFor[int](), expected: -2, err: ErrPrecisionLoss},
+		{from: 200.0, to: reflect.TypeFor[int8](), expected: int8(127), err: ErrOverflow},
+		{from: -200.0, to: reflect.TypeFor[int8](), expected: int8(-128), err: ErrOverflow},
+		{from: -128.5, to: reflect.TypeFor[int8](), expected: int8(-128), err: ErrPrecisionLoss},
+
+		// to unsigned integers
+		{from: "12", to: reflect.TypeFor[uint](), expected: uint(12)},
+		{from: "18446744073709551615", to: reflect.TypeFor[uint64](), expected: uint64(math.MaxUint64)},
+		{from: "-1", to: reflect.TypeFor[uint8](), expected: uint8(255), err: ErrOverflow},
+		{from: false, to: reflect.TypeFor[uint](), expected: uint(0)},
+		{from: "7", to: reflect.TypeFor[uintptr](), expected: uintptr(7)},
+		{from: uintptr(5), to: reflect.TypeFor[int](), expected: 5},
+		{from: uintptr(5), to: reflect.TypeFor[string](), expected: "5"},
+		{from: int64(300), to: reflect.TypeFor[uint8](), expected: uint8(44), err: ErrOverflow},
+		{from: int32(-1), to: reflect.TypeFor[uint32](), expected: uint32(math.MaxUint32), err: ErrOverflow},
+		{from: uint(65535), to: reflect.TypeFor[uint16](), expected: uint16(65535)},
+		{from: uint(65536), to: reflect.TypeFor[uint16](), expected: uint16(0), err: ErrOverflow},
+		{from: 255.0, to: reflect.TypeFor[uint8](), expected: uint8(255)},
+		{from: 256.0, to: reflect.TypeFor[uint8](), expected: uint8(255), err: ErrOverflow},
+		{from: -1.0, to: reflect.TypeFor[uint](), expected: uint(0), err: ErrOverflow},
+		{from: 1.5, to: reflect.TypeFor[uint](), expected: uint(1), err: ErrPrecisionLoss},
+
+		// to floats
+		{from: "1.5", to: reflect.TypeFor[float64](), expected: 1.5},
+		{from: "0.10", to: reflect.TypeFor[float32](), expected: float32(0.1)},
+		{from: "1e400", to: reflect.TypeFor[float64](), expected: math.Inf(1), err: ErrOverflow},
+		{from: "0.1000000000000000000001", to: reflect.TypeFor[float64](), expected: 0.1, err: ErrPrecisionLoss},
+		{from: "abc", to: reflect.TypeFor[float64](), err: strconv.ErrSyntax},
+		{from: true, to: reflect.TypeFor[float32](), expected: float32(1)},
+		{from: 3, to: reflect.TypeFor[float64](), expected: 3.0},
+		{from: int64(1 << 53), to: reflect.TypeFor[float64](), expected: float64(1 << 53)},
+		{from: int64(1<<53 + 1), to: reflect.TypeFor[float64](), expected: float64(1 << 53), err: ErrPrecisionLoss},
+		{from: int32(1<<24 + 1), to: reflect.TypeFor[float32](), expected: float32(1 << 24), err: ErrPrecisionLoss},
+		{from: uint64(math.MaxUint64), to: reflect.TypeFor[float64](), expected: float64(math.MaxUint64), err: ErrPrecisionLoss},
+		{from: 0.5, to: reflect.TypeFor[float32](), expected: float32(0.5)},
+		{from: 0.1, to: reflect.TypeFor[float32](), expected: float32(0.1)},
+		{from: 0.123456789, to: reflect.TypeFor[float32](), expected: float32(0.123456789), err: ErrPrecisionLoss},
+		{from: 1e300, to: reflect.TypeFor[float32](), expected: float32(math.Inf(1)), err: ErrOverflow},
+		{from: math.Inf(-1), to: reflect.TypeFor[float32](), expected: float32(math.Inf(-1))},
+		{from: float32(0.1), to: reflect.TypeFor[float64](), expected: 0.1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%T(%v) to %s", test.from, test.from, test.to), func(t *testing.T) {
+			value := reflect.ValueOf(test.from)
+			got, err := getPrimitiveCoercer(value.Type(), test.to)(value)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+			if test.expected == nil {
+				require.False(t, got.IsValid())
+				return
+			}
+			require.Equal(t, test.expected, got.Interface())
+		})
+	}
+}
+
+func Test_CoerceNaN(t *testing.T) {
+	// NaN is not a number any integer approximates, so is an error rather than an overflow
+	for _, from := range []any{math.NaN(), float32(math.NaN()), "NaN"} {
+		for _, to := range []reflect.Type{reflect.TypeFor[int](), reflect.TypeFor[int8](), reflect.TypeFor[uint](), reflect.TypeFor[uint16]()} {
+			value := reflect.ValueOf(from)
+			got, err := getPrimitiveCoercer(value.Type(), to)(value)
+			require.ErrorContains(t, err, "NaN is not able to be converted to "+nameOf(to))
+			require.False(t, isLossy(err))
+			require.False(t, got.IsValid())
+		}
+	}
+
+	// floats are able to be NaN
+	got, err := Coerce[float32]("NaN")
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(float64(got)))
+}
+
+func Test_CoerceGeneric(t *testing.T) {
+	type count int
+
+	got, err := Coerce[count]("12")
+	require.NoError(t, err)
+	require.Equal(t, count(12), got)
+
+	overflow, err := Coerce[int8](300)
+	require.ErrorIs(t, err, ErrOverflow)
+	require.Equal(t, int8(44), overflow)
+
+	invalid, err := Coerce[int]("abc")
+	require.Error(t, err)
+	require.Zero(t, invalid)
+
+	addr, err := Coerce[int](uintptr(5))
+	require.NoError(t, err)
+	require.Equal(t, 5, addr)
+}
+
+func Test_FormatPrimitive(t *testing.T) {
+	tests := []any{"str", true, -12, int8(3), uint16(7), uintptr(9), 1.5, float32(0.1), 1e21}
+	for _, value := range tests {
+		require.Equal(t, fmt.Sprintf("%v", value), formatPrimitive(reflect.ValueOf(value)))
+	}
+}
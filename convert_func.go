@@ -1,36 +1,173 @@
 package converter
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
+	"sync"
+	"sync/atomic"
 )
 
 type FuncChain interface {
 	AddConverter(converter ...any) FuncChain
+	// AddConverterWithCost adds converters with the given cost, which must not be negative; converters added with
+	// AddConverter have a cost of 1. When multiple paths are available between two types, Convert uses the path with
+	// the lowest total cost. Ties are broken by using the path with the fewest conversions, then by preferring the
+	// converters registered first, comparing each step of the paths in order.
+	AddConverterWithCost(cost int, converter ...any) FuncChain
+	// AddCoercer adds functions of the form func(from Type1) (Type2, error), which convert values of one type to
+	// another type of any kind, e.g. a string to a [16]byte or to a named enum type. Coercers are checked before any
+	// other conversion of a value, and are called for every value of the type, including zero values and pointers to
+	// them. The types must be different and must not be pointers, a coercer is used for pointers to the types the same
+	// as for the values.
+	AddCoercer(coercer ...any) FuncChain
 	AutoPackageConverter(fromPkg, toPkg any) FuncChain
+	// RegisterImplementations registers the types implementing an interface which values are converted to when the
+	// target is the interface, such as a field, slice element or map value with the interface type. The interface is
+	// provided as a nil pointer to it, e.g. (*Relationship)(nil). Each value is converted to the implementation chosen
+	// by the discriminator, which may be nil, otherwise to its own type if that is registered, otherwise to the only
+	// implementation its type has a conversion path to. A value with conversion paths to several implementations is
+	// ambiguous and results in an ErrAmbiguousImplementation error, and a value with none results in an
+	// ErrNoImplementation error.
+	RegisterImplementations(iface any, discriminator Discriminator, impls ...any) FuncChain
+	// UseHub declares a hub type which every other version is converted through, so each version only needs
+	// converters to and from the hub rather than to every other version. Between two types other than the hub, a
+	// converter registered directly between them is used, otherwise the value is converted to the hub and then from the
+	// hub to the target type, each using the lowest cost path. Other paths are only used when there is no path through
+	// the hub.
+	UseHub(hub any) FuncChain
 	AllowImplicit() FuncChain
-	Convert(from any, to any) error
+	// PreserveZeroValues keeps zero values when converting rather than leaving the target unset: a non-nil pointer to
+	// a zero value, such as &false or &Struct{}, is converted to a non-nil pointer, and a zero value converted to a
+	// different primitive type is converted the same as any other value, e.g. &false to &"false", except an empty
+	// string, which is the zero value of the other type.
+	PreserveZeroValues() FuncChain
+	// Convert converts the from value to the to value, which must be a pointer. Options may be provided to change how
+	// this conversion is done, without affecting other uses of the chain.
+	Convert(from any, to any, opts ...ConvertOption) error
+	// Freeze makes the chain immutable, any further attempts to modify it will panic. All conversion methods and
+	// interface resolutions for the registered types are computed up front, so a frozen chain is able to be used
+	// concurrently without any additional work being done during Convert. A chain that is not frozen is still safe
+	// to use concurrently, but needs to synchronize access to these lazily computed values. Types which are only
+	// connected through conversion methods are not reachable from registered converters, so may be provided here.
+	Freeze(types ...any) FuncChain
 }
 
 type funcChain struct {
+	lock                    sync.RWMutex
+	frozen                  atomic.Bool // frozen chains are not modified, so are read without locking
 	allowImplicitConversion bool
-	funcs                   map[reflect.Type]map[reflect.Type]func(from reflect.Value, to reflect.Value) error
+	preserveZeroValues      bool
+	funcs                   map[reflect.Type]map[reflect.Type]reflectConvertStep
+	registered              int
+	inspected               map[reflect.Type]bool
+	pending                 []reflect.Type
+	interfaces              map[reflect.Type]map[reflect.Type]reflect.Type
+	implementations         map[reflect.Type]implementations
+	hub                     reflect.Type
+	versions                []reflect.Type
+	routes                  sync.Map // typePair -> []reflectConvertStep
+	// coercers are replaced rather than modified, so conversions are able to use them without locking
+	coercers map[typePair]reflect.Value
 }
 
 func NewFuncChain(converters ...any) FuncChain {
-	out := funcChain{
-		funcs: map[reflect.Type]map[reflect.Type]func(from reflect.Value, to reflect.Value) error{},
+	out := &funcChain{
+		funcs:           map[reflect.Type]map[reflect.Type]reflectConvertStep{},
+		inspected:       map[reflect.Type]bool{},
+		interfaces:      map[reflect.Type]map[reflect.Type]reflect.Type{},
+		implementations: map[reflect.Type]implementations{},
 	}
 	return out.AddConverter(converters...)
 }
 
 func (c *funcChain) AllowImplicit() FuncChain {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.assertMutable()
+
 	c.allowImplicitConversion = true
+	c.routes.Clear()
+	return c
+}
+
+func (c *funcChain) UseHub(hub any) FuncChain {
+	hubType := reflect.TypeOf(hub)
+	if hubType == nil || !isStruct(baseType(hubType)) {
+		panic(fmt.Errorf("hub must be a struct; got: %v", hubType))
+	}
+	hubType = baseType(hubType)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.assertMutable()
+
+	if c.hub != nil && c.hub != hubType {
+		panic(fmt.Errorf("hub already declared as %s; got: %s", nameOf(c.hub), nameOf(hubType)))
+	}
+	c.hub = hubType
+	// the hub is inspected for conversion methods
+	c.pending = append(c.pending, hubType)
+	c.routes.Clear()
+	return c
+}
+
+func (c *funcChain) PreserveZeroValues() FuncChain {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.assertMutable()
+
+	c.preserveZeroValues = true
+	return c
+}
+
+func (c *funcChain) Freeze(types ...any) FuncChain {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.frozen.Load() {
+		return c
+	}
+
+	for _, t := range types {
+		c.pending = append(c.pending, baseType(reflect.TypeOf(t)))
+	}
+
+	// find all types reachable from the registered conversions, along with any interfaces they contain
+	var interfaces []reflect.Type
+	seen := map[reflect.Type]bool{}
+	for len(c.pending) > 0 {
+		types := c.pending
+		c.pending = nil
+		for _, t := range types {
+			walkTypes(t, seen, func(t reflect.Type) {
+				if isInterface(t) {
+					interfaces = append(interfaces, t)
+					return
+				}
+				c.addConvertMethods(t)
+			})
+		}
+	}
+
+	for fromType := range c.funcs {
+		for _, iface := range interfaces {
+			c.cacheInterface(fromType, iface, c.findConvertableType(fromType, iface))
+		}
+	}
+
+	c.frozen.Store(true)
 	return c
 }
 
+func (c *funcChain) assertMutable() {
+	if c.frozen.Load() {
+		panic(fmt.Errorf("unable to modify a frozen chain"))
+	}
+}
+
 func (c *funcChain) AutoPackageConverter(fromPkg, toPkg any) FuncChain {
 	fromTypes := map[string]reflect.Type{}
 	toTypes := map[string]reflect.Type{}
@@ -43,30 +180,35 @@ func (c *funcChain) AutoPackageConverter(fromPkg, toPkg any) FuncChain {
 	}
 
 	for t := range listAllBaseTypes() {
-		if t.PkgPath() == fromPkg {
+		if t.PkgPath() == fromName {
 			fromTypes[t.Name()] = t
 		}
-		if t.PkgPath() == toPkg {
+		if t.PkgPath() == toName {
 			toTypes[t.Name()] = t
 		}
 	}
 
-	for name, fromT := range fromTypes {
+	// register in a consistent order, which is used to choose between otherwise equal conversion paths
+	for _, name := range slices.Sorted(maps.Keys(fromTypes)) {
+		fromT := fromTypes[name]
 		toT, ok := toTypes[name]
 		if !ok {
 			continue
 		}
 
 		// this does nothing other than inform the types
-		c.AddConvertFunc(fromT, toT, func(_ reflect.Value, _ reflect.Value) error {
+		c.lock.Lock()
+		c.assertMutable()
+		c.addConvertFunc(fromT, toT, defaultCost, &convertOrigin{noop: true}, func(_ reflect.Value, _ reflect.Value) error {
 			return nil
 		})
+		c.lock.Unlock()
 	}
 
 	return c
 }
 
-func (c *funcChain) Convert(from any, to any) error {
+func (c *funcChain) Convert(from any, to any, opts ...ConvertOption) error {
 	fromValue := reflect.ValueOf(from)
 	fromType := fromValue.Type()
 	baseFromType := baseType(fromType)
@@ -75,21 +217,34 @@ func (c *funcChain) Convert(from any, to any) error {
 	toType := toValue.Type()
 	baseToType := baseType(toType)
 
+	// find any conversion methods on the types involved
+	c.inspectTypes(baseFromType, baseToType)
+
 	// build the shortest path between types
-	chain := c.shortestChain(baseFromType, baseToType)
+	chain := c.route(baseFromType, baseToType)
 
 	// no explicit conversions
 	if len(chain) == 0 {
 		return fmt.Errorf("no conversion path found from %s to %s", typeName(baseFromType), typeName(baseToType))
 	}
 
+	c.lock.RLock()
 	cnv := conversion{
 		chain: c,
 	}
+	cnv.preserveZeroValues = c.preserveZeroValues
+	cnv.coercers = c.coercers
+	c.lock.RUnlock()
+	for _, opt := range opts {
+		opt(&cnv.convertOptions)
+	}
 
 	// iterate, creating any intermediary structs for the migration
 	last := fromValue
 	for i, step := range chain {
+		cnv.hop = i
+		// pointers are only shared between values of a single step
+		cnv.visited = nil
 		var next reflect.Value
 		if i == len(chain)-1 {
 			next = toValue
@@ -98,20 +253,116 @@ func (c *funcChain) Convert(from any, to any) error {
 		}
 
 		cnv.convert(last, next)
-		last = next
+		if cnv.stopped() {
+			break
+		}
+		// intermediate values are always converted, even when empty
+		last = next.Elem()
 	}
 
 	return errors.Join(cnv.errors...)
 }
 
+// inspectTypes looks for conversion methods on the provided types and all types with registered conversions
+func (c *funcChain) inspectTypes(types ...reflect.Type) {
+	if c.frozen.Load() {
+		return
+	}
+
+	c.lock.RLock()
+	done := len(c.pending) == 0
+	for _, t := range types {
+		done = done && c.inspected[t]
+	}
+	c.lock.RUnlock()
+	if done {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.frozen.Load() {
+		return
+	}
+	types = append(types, c.pending...)
+	c.pending = nil
+	for _, t := range types {
+		c.addConvertMethods(t)
+	}
+}
+
+// convertFunc returns the conversion function registered from one type to another, or nil
+func (c *funcChain) convertFunc(fromType, toType reflect.Type) reflectConvertFunc {
+	if !c.frozen.Load() {
+		c.lock.RLock()
+		defer c.lock.RUnlock()
+	}
+	return c.funcs[fromType][toType].convertFunc
+}
+
+// resolveInterface returns the concrete type a value of fromType should be converted to in order to satisfy the
+// interface type, or nil if there is not exactly one registered conversion target satisfying the interface
+func (c *funcChain) resolveInterface(fromType, iface reflect.Type) reflect.Type {
+	if c.frozen.Load() {
+		if resolved, cached := c.interfaces[fromType][iface]; cached {
+			return resolved
+		}
+		return c.findConvertableType(fromType, iface)
+	}
+
+	c.lock.RLock()
+	resolved, cached := c.interfaces[fromType][iface]
+	if !cached {
+		resolved = c.findConvertableType(fromType, iface)
+	}
+	c.lock.RUnlock()
+
+	if !cached {
+		c.lock.Lock()
+		c.cacheInterface(fromType, iface, resolved)
+		c.lock.Unlock()
+	}
+	return resolved
+}
+
+func (c *funcChain) cacheInterface(fromType, iface, resolved reflect.Type) {
+	cache := c.interfaces[fromType]
+	if cache == nil {
+		cache = map[reflect.Type]reflect.Type{}
+		c.interfaces[fromType] = cache
+	}
+	cache[iface] = resolved
+}
+
+func (c *funcChain) findConvertableType(fromType reflect.Type, targetType reflect.Type) reflect.Type {
+	var found reflect.Type
+	for target := range c.funcs[fromType] {
+		if target.AssignableTo(targetType) {
+			if found != nil {
+				// found multiple
+				return nil
+			}
+			found = target
+		}
+	}
+	return found
+}
+
 func (c *funcChain) AddConverter(converters ...any) FuncChain {
+	return c.AddConverterWithCost(defaultCost, converters...)
+}
+
+func (c *funcChain) AddConverterWithCost(cost int, converters ...any) FuncChain {
+	if cost < 0 {
+		panic(fmt.Errorf("converter cost must not be negative; got: %d", cost))
+	}
 	for _, converter := range converters {
-		c.addConverter(converter)
+		c.addConverter(converter, cost)
 	}
 	return c
 }
 
-func (c *funcChain) addConverter(converter any) {
+func (c *funcChain) addConverter(converter any, cost int) {
 	convertFunc := reflect.ValueOf(converter)
 	convertFuncType := convertFunc.Type()
 	if validationError := validateConvertFunc(convertFuncType); validationError != nil {
@@ -140,8 +391,19 @@ func (c *funcChain) addConverter(converter any) {
 		toType = convertFuncType.In(2)
 	}
 
-	c.AddConvertFunc(fromType, toType, func(from reflect.Value, to reflect.Value) error {
-		// setup matching args, from and to should already be set up properly
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.assertMutable()
+
+	origin := &convertOrigin{
+		fn:            convertFunc,
+		hasChainParam: hasChainParam,
+	}
+
+	c.addConvertFunc(fromType, toType, cost, origin, func(from reflect.Value, to reflect.Value) error {
+		// setup matching args, from may need to be provided as a pointer
+		from = asArg(from, fromType)
+		to = asArg(to, toType)
 		var args []reflect.Value
 		if hasChainParam {
 			args = []reflect.Value{reflect.ValueOf(c), from, to}
@@ -160,49 +422,192 @@ func (c *funcChain) addConverter(converter any) {
 	})
 }
 
+func (c *funcChain) AddCoercer(coercers ...any) FuncChain {
+	for _, coercer := range coercers {
+		c.addCoercer(coercer)
+	}
+	return c
+}
+
+func (c *funcChain) addCoercer(coercer any) {
+	fn := reflect.ValueOf(coercer)
+	fnType := fn.Type()
+	if validationError := validateCoercer(fnType); validationError != nil {
+		panic(fmt.Errorf(`coercer must be a function of the form:
+			func(from Type1) (Type2, error)
+
+			got: %+v
+			err: %v
+		`, fnType, validationError))
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.assertMutable()
+
+	key := typePair{fnType.In(0), fnType.Out(0)}
+	if _, exists := c.coercers[key]; exists {
+		panic(fmt.Errorf("coercer from: %s -> %s defined multiple times", typeName(key.from), typeName(key.to)))
+	}
+	coercers := maps.Clone(c.coercers)
+	if coercers == nil {
+		coercers = map[typePair]reflect.Value{}
+	}
+	coercers[key] = fn
+	c.coercers = coercers
+
+	// a coercer is able to convert the types directly
+	c.routes.Clear()
+}
+
 func (c *funcChain) AddConvertFunc(fromType, toType reflect.Type, fn func(from reflect.Value, to reflect.Value) error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.assertMutable()
+
+	c.addConvertFunc(fromType, toType, defaultCost, nil, fn)
+}
+
+func (c *funcChain) addConvertFunc(fromType, toType reflect.Type, cost int, origin *convertOrigin, fn func(from reflect.Value, to reflect.Value) error) {
 	baseFromType := baseType(fromType)
 	baseToType := baseType(toType)
 
 	convertFuncs := c.funcs[baseFromType]
 	if convertFuncs == nil {
-		convertFuncs = map[reflect.Type]func(from reflect.Value, to reflect.Value) error{}
+		convertFuncs = map[reflect.Type]reflectConvertStep{}
 		c.funcs[baseFromType] = convertFuncs
 	}
 
-	if convertFuncs[baseToType] != nil {
+	if existing, exists := convertFuncs[baseToType]; exists && !existing.origin.replaceableBy(origin) {
 		panic(fmt.Errorf("convert from: %s -> %s defined multiple times; %+v", typeName(baseFromType), typeName(baseToType), reflect.TypeFor[func(from reflect.Value, to reflect.Value) error]()))
 	}
 
-	convertFuncs[baseToType] = fn
+	c.registered++
+	convertFuncs[baseToType] = reflectConvertStep{
+		targetType:  baseToType,
+		convertFunc: fn,
+		cost:        cost,
+		order:       c.registered,
+		origin:      origin,
+	}
+
+	// these will be inspected for conversion methods
+	c.pending = append(c.pending, baseFromType, baseToType)
+
+	// the cached interface resolutions and routes may change with a new conversion
+	clear(c.interfaces)
+	c.routes.Clear()
 }
 
-func (c *funcChain) shortestChain(fromType reflect.Type, targetType reflect.Type, visited ...reflect.Type) []reflectConvertStep {
-	var shortest []reflectConvertStep
-	for toType, convertFunc := range c.funcs[fromType] {
-		if slices.Contains(visited, toType) {
-			continue
+// shortestChain finds the path of conversions between the types: through the hub when one is declared, see UseHub,
+// otherwise the lowest cost path, falling back to a direct conversion when implicit conversions are allowed. The chain
+// must be locked for reading.
+func (c *funcChain) shortestChain(fromType reflect.Type, targetType reflect.Type) []reflectConvertStep {
+	if steps := c.hubChain(fromType, targetType); steps != nil {
+		return steps
+	}
+	if steps := c.lowestCostChain(fromType, targetType); steps != nil {
+		return steps
+	}
+
+	// no explicit conversions, try a direct conversion
+	if _, ok := c.coercers[typePair{fromType, targetType}]; ok || c.allowImplicitConversion {
+		return []reflectConvertStep{{
+			targetType: fromType,
+			convertFunc: func(_ reflect.Value, _ reflect.Value) error {
+				return nil
+			},
+		}}
+	}
+	return nil
+}
+
+// hubChain returns the path of conversions between two types other than the hub through the hub, or the converter
+// registered directly between them, see UseHub
+func (c *funcChain) hubChain(fromType reflect.Type, targetType reflect.Type) []reflectConvertStep {
+	if c.hub == nil || fromType == targetType || fromType == c.hub || targetType == c.hub {
+		return nil
+	}
+	if step, ok := c.funcs[fromType][targetType]; ok {
+		return []reflectConvertStep{step}
+	}
+	toHub := c.lowestCostChain(fromType, c.hub)
+	if toHub == nil {
+		return nil
+	}
+	// the path to the hub may already pass through the target type
+	if i := slices.IndexFunc(toHub, func(step reflectConvertStep) bool { return step.targetType == targetType }); i >= 0 {
+		return toHub[:i+1]
+	}
+	fromHub := c.lowestCostChain(c.hub, targetType)
+	if fromHub == nil {
+		return nil
+	}
+	return append(slices.Clip(toHub), fromHub...)
+}
+
+// lowestCostChain finds the lowest cost path of conversions between the types, see AddConverterWithCost for how ties
+// are broken. The chain must be locked for reading.
+func (c *funcChain) lowestCostChain(fromType reflect.Type, targetType reflect.Type) []reflectConvertStep {
+	// a simple Dijkstra's algorithm, the number of types is expected to be small
+	best := map[reflect.Type]convertPath{fromType: {}}
+	done := map[reflect.Type]bool{}
+	for {
+		var current reflect.Type
+		for t, path := range best {
+			if !done[t] && (current == nil || path.compare(best[current]) < 0) {
+				current = t
+			}
 		}
-		if toType == targetType {
-			return []reflectConvertStep{{toType, convertFunc}}
+		if current == nil {
+			break
 		}
-		chain := c.shortestChain(toType, targetType, append(visited, fromType)...)
-		if chain == nil {
-			continue
+		if current == targetType {
+			if current == fromType {
+				// converting to the same type
+				break
+			}
+			return best[current].steps
 		}
-		// this is a viable conversion chain, use it if it's shorter or we haven't found any yet
-		chain = append([]reflectConvertStep{{toType, convertFunc}}, chain...)
-		if shortest == nil || len(chain) < len(shortest) {
-			shortest = chain
+		done[current] = true
+
+		for toType, step := range c.funcs[current] {
+			path := best[current].append(step)
+			if existing, ok := best[toType]; !ok || path.compare(existing) < 0 {
+				best[toType] = path
+			}
 		}
 	}
-	// no explicit conversions, try a direct conversion
-	if len(shortest) == 0 && c.allowImplicitConversion {
-		return []reflectConvertStep{{fromType, func(_ reflect.Value, _ reflect.Value) error {
-			return nil
-		}}}
+	return nil
+}
+
+// convertPath is a sequence of conversion steps, along with the total cost
+type convertPath struct {
+	steps []reflectConvertStep
+	cost  int
+}
+
+func (p convertPath) append(step reflectConvertStep) convertPath {
+	return convertPath{
+		steps: append(slices.Clip(p.steps), step),
+		cost:  p.cost + step.cost,
+	}
+}
+
+// compare orders paths by lowest cost, then fewest steps, then by the registration order of each step
+func (p convertPath) compare(other convertPath) int {
+	if c := cmp.Compare(p.cost, other.cost); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(len(p.steps), len(other.steps)); c != 0 {
+		return c
 	}
-	return shortest
+	for i := range p.steps {
+		if c := cmp.Compare(p.steps[i].order, other.steps[i].order); c != 0 {
+			return c
+		}
+	}
+	return 0
 }
 
 var chainType = reflect.TypeFor[FuncChain]()
@@ -251,6 +656,52 @@ func validateConvertFunc(t reflect.Type) error {
 	return nil
 }
 
+func validateCoercer(t reflect.Type) error {
+	if t.Kind() != reflect.Func {
+		return fmt.Errorf("not a function")
+	}
+	if t.NumIn() != 1 {
+		return fmt.Errorf("must have 1 argument")
+	}
+	if t.NumOut() != 2 || t.Out(1) != errorInterface {
+		return fmt.Errorf("must return the converted value and an error")
+	}
+	fromType := t.In(0)
+	toType := t.Out(0)
+	if isPtr(fromType) || isPtr(toType) {
+		return fmt.Errorf("must convert between types which are not pointers")
+	}
+	if fromType == toType {
+		return fmt.Errorf("coerce should be between different types")
+	}
+	return nil
+}
+
+// walkTypes calls visit for the type and all the types it is composed of: pointer, slice, array and map elements,
+// and exported struct fields
+func walkTypes(t reflect.Type, seen map[reflect.Type]bool, visit func(reflect.Type)) {
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+	visit(t)
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		walkTypes(t.Elem(), seen, visit)
+	case reflect.Map:
+		walkTypes(t.Key(), seen, visit)
+		walkTypes(t.Elem(), seen, visit)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() {
+				walkTypes(f.Type, seen, visit)
+			}
+		}
+	default:
+	}
+}
+
 func pkgName(pkg any) string {
 	switch p := pkg.(type) {
 	case string:
@@ -273,4 +724,46 @@ type reflectConvertFunc func(from reflect.Value, to reflect.Value) error
 type reflectConvertStep struct {
 	targetType  reflect.Type
 	convertFunc reflectConvertFunc
+	cost        int
+	order       int
+	origin      *convertOrigin
 }
+
+// convertOrigin describes what a registered conversion function invokes, which is used to generate code calling the
+// same function. This is nil for conversions registered directly with AddConvertFunc.
+type convertOrigin struct {
+	// noop indicates the conversion does nothing except connect the types
+	noop bool
+	// neighbor indicates the conversion connects consecutive versions of a VersionedChain, so is replaced by any
+	// conversion registered between the same types
+	neighbor bool
+	// fn is the converter function, when registered with AddConverter
+	fn reflect.Value
+	// method is the ConvertFrom or ConvertTo method, when discovered on a type
+	method *reflect.Method
+	// isConvertFrom indicates the method is ConvertFrom, so is called on the target
+	isConvertFrom bool
+	// hasChainParam indicates the function or method accepts a FuncChain
+	hasChainParam bool
+}
+
+// replaceableBy indicates the conversion is replaced by the other conversion registered between the same types: the
+// implicit conversions between versions are replaced by any other conversion, and conversion methods are replaced by
+// explicitly added converters
+func (o *convertOrigin) replaceableBy(other *convertOrigin) bool {
+	switch {
+	case o == nil:
+		return false
+	case o.neighbor:
+		return true
+	}
+	return o.method != nil && (other == nil || other.method == nil)
+}
+
+// isNoop indicates the conversion does nothing except connect the types
+func (o *convertOrigin) isNoop() bool {
+	return o != nil && o.noop
+}
+
+// defaultCost is the cost of converters which have not been given one explicitly
+const defaultCost = 1
@@ -1,9 +1,15 @@
 package converter
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	v1 "github.com/anchore/go-struct-converter/internal/autopkg/v1"
+	v2 "github.com/anchore/go-struct-converter/internal/autopkg/v2"
 )
 
 func Test_FuncChainErrors(t *testing.T) {
@@ -141,3 +147,507 @@ func t3ToT5(_ FuncChain, _ t3, t5 *t5) error {
 	t5.Name = "FromT3"
 	return nil
 }
+
+func Test_FuncChainFreeze(t *testing.T) {
+	chain := NewFuncChain(t1ToT2, t2ToT3).Freeze()
+
+	require.Panics(t, func() {
+		chain.AddConverter(t3ToT2)
+	})
+	require.Panics(t, func() {
+		chain.AllowImplicit()
+	})
+
+	to := t3{}
+	require.NoError(t, chain.Convert(t1{Name: "name", Custom1: "custom"}, &to))
+	require.Equal(t, t3{Name: "name", Custom3: "custom"}, to)
+
+	// conversion methods must be reachable from the registered types, or provided
+	methods := NewFuncChain().Freeze(mv3{})
+	got := mv3{}
+	require.NoError(t, methods.Convert(mv1{Name: "name", OldField: "value"}, &got))
+	require.Equal(t, []string{"value"}, got.FinalField)
+}
+
+func Test_FuncChainConcurrentConvert(t *testing.T) {
+	type source struct {
+		Name  string
+		Value any
+		Items []mv1
+	}
+	type target struct {
+		Name  string
+		Value fmt.Stringer
+		Items []mv2
+	}
+
+	tests := []struct {
+		name  string
+		chain func() FuncChain
+	}{
+		{
+			name: "frozen",
+			chain: func() FuncChain {
+				return NewFuncChain(func(from stringerFrom, to *stringerTo) {
+					to.Value = from.Value
+				}).AllowImplicit().Freeze(source{}, target{})
+			},
+		},
+		{
+			name: "not frozen",
+			chain: func() FuncChain {
+				return NewFuncChain(func(from stringerFrom, to *stringerTo) {
+					to.Value = from.Value
+				}).AllowImplicit()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := test.chain()
+
+			const goroutines = 8
+			results := make(chan error, goroutines)
+			for i := range goroutines {
+				go func() {
+					from := source{
+						Name:  fmt.Sprintf("name-%d", i),
+						Items: []mv1{{Name: "item", OldField: "old"}},
+					}
+					to := target{}
+					err := chain.Convert(from, &to)
+					if err == nil && (to.Name != from.Name || to.Items[0].NewField != "old") {
+						err = fmt.Errorf("unexpected result: %+v", to)
+					}
+					results <- err
+				}()
+			}
+			for range goroutines {
+				require.NoError(t, <-results)
+			}
+		})
+	}
+}
+
+func Test_FuncChainInterfaceResolution(t *testing.T) {
+	type source struct {
+		Value stringerFrom
+	}
+	type target struct {
+		Value fmt.Stringer
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+
+	// no converter registered yet, so the interface can't be resolved
+	to := target{}
+	require.NoError(t, chain.Convert(source{Value: stringerFrom{Value: "v"}}, &to))
+	require.Nil(t, to.Value)
+
+	// the previous miss must not prevent resolution after a new converter is added
+	chain.AddConverter(func(from stringerFrom, to *stringerTo) {
+		to.Value = from.Value
+	})
+	require.NoError(t, chain.Convert(source{Value: stringerFrom{Value: "v"}}, &to))
+	require.Equal(t, stringerTo{Value: "v"}, to.Value)
+}
+
+type stringerFrom struct {
+	Value string
+}
+
+type stringerTo struct {
+	Value string
+}
+
+func (s stringerTo) String() string {
+	return s.Value
+}
+
+func Test_FuncChainPathSelection(t *testing.T) {
+	type v3 struct{ Path []string }
+	type v4 struct{ Path []string }
+	type v5 struct{ Path []string }
+	type v6 struct{ Path []string }
+
+	v3ToV4 := func(from v3, to *v4) { to.Path = append(from.Path, "v4") }
+	v4ToV5 := func(from v4, to *v5) { to.Path = append(from.Path, "v5") }
+	v3ToV6 := func(from v3, to *v6) { to.Path = append(from.Path, "v6") }
+	v6ToV5 := func(from v6, to *v5) { to.Path = append(from.Path, "v5") }
+
+	tests := []struct {
+		name     string
+		chain    func() FuncChain
+		expected []string
+	}{
+		{
+			name: "equal paths use the first registered",
+			chain: func() FuncChain {
+				return NewFuncChain(v3ToV4, v4ToV5, v3ToV6, v6ToV5)
+			},
+			expected: []string{"v3", "v4", "v5"},
+		},
+		{
+			name: "equal paths use the first registered, reversed",
+			chain: func() FuncChain {
+				return NewFuncChain(v3ToV6, v6ToV5, v3ToV4, v4ToV5)
+			},
+			expected: []string{"v3", "v6", "v5"},
+		},
+		{
+			name: "first differing step decides",
+			chain: func() FuncChain {
+				return NewFuncChain(v6ToV5, v3ToV4, v4ToV5, v3ToV6)
+			},
+			expected: []string{"v3", "v4", "v5"},
+		},
+		{
+			name: "lower cost is used",
+			chain: func() FuncChain {
+				return NewFuncChain(v3ToV4, v4ToV5).AddConverterWithCost(0, v3ToV6, v6ToV5)
+			},
+			expected: []string{"v3", "v6", "v5"},
+		},
+		{
+			name: "fewer steps with equal cost",
+			chain: func() FuncChain {
+				return NewFuncChain(v3ToV4, v4ToV5, v3ToV6, v6ToV5).AddConverterWithCost(2, func(from v3, to *v5) {
+					to.Path = append(from.Path, "v5")
+				})
+			},
+			expected: []string{"v3", "v5"},
+		},
+		{
+			name: "higher cost direct conversion is avoided",
+			chain: func() FuncChain {
+				return NewFuncChain(v3ToV4, v4ToV5).AddConverterWithCost(3, func(from v3, to *v5) {
+					to.Path = append(from.Path, "v5")
+				})
+			},
+			expected: []string{"v3", "v4", "v5"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := test.chain()
+			for range 20 {
+				to := v5{}
+				require.NoError(t, chain.Convert(v3{Path: []string{"v3"}}, &to))
+				require.Equal(t, test.expected, to.Path)
+			}
+		})
+	}
+}
+
+func Test_FuncChainEmptyIntermediateValues(t *testing.T) {
+	type v1 struct{ Name string }
+	type v2 struct{ Name string }
+	type v3 struct {
+		Name    string
+		Version string
+	}
+
+	// the intermediate v2 is empty, but is still converted to v3
+	chain := NewFuncChain(func(_ v1, _ *v2) {}, func(_ v2, to *v3) {
+		to.Version = "3"
+	})
+
+	to := v3{}
+	require.NoError(t, chain.Convert(v1{}, &to))
+	require.Equal(t, v3{Version: "3"}, to)
+}
+
+func Test_FuncChainPointerArguments(t *testing.T) {
+	type v1 struct{ Name string }
+	type v2 struct{ Label string }
+
+	chain := NewFuncChain(func(from *v1, to *v2) {
+		to.Label = "label: " + from.Name
+	})
+
+	to := v2{}
+	require.NoError(t, chain.Convert(v1{Name: "name"}, &to))
+	require.Equal(t, v2{Label: "label: name"}, to)
+}
+
+func Test_FuncChainNegativeCost(t *testing.T) {
+	require.Panics(t, func() {
+		NewFuncChain().AddConverterWithCost(-1, t1ToT2)
+	})
+}
+
+func Test_FuncChainHub(t *testing.T) {
+	type hub struct{ Path []string }
+	type v1 struct{ Path []string }
+	type v2 struct{ Path []string }
+	type v3 struct{ Path []string }
+
+	v1ToHub := func(from v1, to *hub) { to.Path = append(from.Path, "hub") }
+	hubToV1 := func(from hub, to *v1) { to.Path = append(from.Path, "v1") }
+	v2ToHub := func(from v2, to *hub) { to.Path = append(from.Path, "hub") }
+	hubToV2 := func(from hub, to *v2) { to.Path = append(from.Path, "v2") }
+	v3ToHub := func(from v3, to *hub) { to.Path = append(from.Path, "hub") }
+	hubToV3 := func(from hub, to *v3) { to.Path = append(from.Path, "v3") }
+	v1ToV2 := func(from v1, to *v2) { to.Path = append(from.Path, "v2") }
+	v2ToV3 := func(from v2, to *v3) { to.Path = append(from.Path, "v3") }
+	spokes := []any{v1ToHub, hubToV1, v2ToHub, hubToV2, v3ToHub, hubToV3}
+
+	tests := []struct {
+		name     string
+		chain    func() FuncChain
+		expected []string
+	}{
+		{
+			name: "without a hub, the first registered path is used",
+			chain: func() FuncChain {
+				return NewFuncChain(v1ToV2, v2ToV3).AddConverter(spokes...)
+			},
+			expected: []string{"v1", "v2", "v3"},
+		},
+		{
+			name: "spokes are converted through the hub",
+			chain: func() FuncChain {
+				return NewFuncChain(v1ToV2, v2ToV3).AddConverter(spokes...).UseHub(hub{})
+			},
+			expected: []string{"v1", "hub", "v3"},
+		},
+		{
+			name: "direct conversions are used over the hub",
+			chain: func() FuncChain {
+				return NewFuncChain(spokes...).AddConverterWithCost(5, func(from v1, to *v3) {
+					to.Path = append(from.Path, "v3")
+				}).UseHub(hub{})
+			},
+			expected: []string{"v1", "v3"},
+		},
+		{
+			name: "other paths are used without a path through the hub",
+			chain: func() FuncChain {
+				return NewFuncChain(v1ToV2, v2ToV3, v3ToHub, hubToV3).UseHub(&hub{})
+			},
+			expected: []string{"v1", "v2", "v3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain := test.chain()
+			for range 20 {
+				to := v3{}
+				require.NoError(t, chain.Convert(v1{Path: []string{"v1"}}, &to))
+				require.Equal(t, test.expected, to.Path)
+			}
+		})
+	}
+
+	// and backwards through the hub
+	got := v1{}
+	require.NoError(t, NewFuncChain(spokes...).UseHub(hub{}).Convert(v3{Path: []string{"v3"}}, &got))
+	require.Equal(t, []string{"v3", "hub", "v1"}, got.Path)
+
+	require.Panics(t, func() {
+		NewFuncChain().UseHub("hub")
+	})
+	require.Panics(t, func() {
+		NewFuncChain().UseHub(hub{}).UseHub(v1{})
+	})
+	require.Panics(t, func() {
+		NewFuncChain().Freeze().UseHub(hub{})
+	})
+}
+
+type coercedColor int
+
+const (
+	colorRed coercedColor = iota + 1
+	colorBlue
+)
+
+func Test_FuncChainCoercers(t *testing.T) {
+	type from struct {
+		ID     string
+		Color  string
+		Colors []string
+		Count  int
+	}
+	type to struct {
+		ID     [4]byte
+		Color  coercedColor
+		Colors []*coercedColor
+		Count  string
+	}
+
+	chain := NewFuncChain().AllowImplicit().AddCoercer(
+		func(s string) ([4]byte, error) {
+			var out [4]byte
+			if len(s) > len(out) {
+				return out, fmt.Errorf("id too long: %s", s)
+			}
+			copy(out[:], s)
+			return out, nil
+		},
+		func(s string) (coercedColor, error) {
+			switch s {
+			case "red":
+				return colorRed, nil
+			case "blue":
+				return colorBlue, nil
+			}
+			return 0, fmt.Errorf("unknown color: %s", s)
+		},
+		// replaces the built-in conversion
+		func(i int) (string, error) {
+			return fmt.Sprintf("#%d", i), nil
+		},
+	)
+
+	got := to{}
+	require.NoError(t, chain.Convert(from{ID: "ab", Color: "red", Colors: []string{"blue"}, Count: 3}, &got))
+	require.Equal(t, to{ID: [4]byte{'a', 'b'}, Color: colorRed, Colors: []*coercedColor{ptr(colorBlue)}, Count: "#3"}, got)
+
+	err := chain.Convert(from{ID: "abcde", Color: "red", Colors: []string{"green"}}, &to{})
+	require.ErrorContains(t, err, "id too long: abcde")
+	var convErr *ConversionError
+	require.ErrorAs(t, err, &convErr)
+	require.Equal(t, "ID", convErr.Path)
+	require.ErrorContains(t, err, "converting Colors[0] from string to converter.coercedColor (step 0): unknown color: green")
+
+	// coercers are able to convert the values directly, without any other conversion path
+	color := coercedColor(0)
+	require.NoError(t, NewFuncChain().AddCoercer(func(_ string) (coercedColor, error) {
+		return colorBlue, nil
+	}).Convert("blue", &color))
+	require.Equal(t, colorBlue, color)
+
+	// coercers are called for zero values, including those referred to by pointers
+	type settings struct {
+		Enabled *bool
+		Visible bool
+	}
+	type labels struct {
+		Enabled string
+		Visible string
+	}
+	got2 := labels{}
+	require.NoError(t, NewFuncChain().AllowImplicit().AddCoercer(func(b bool) (string, error) {
+		if b {
+			return "yes", nil
+		}
+		return "no", nil
+	}).Convert(settings{Enabled: new(bool)}, &got2))
+	require.Equal(t, labels{Enabled: "no", Visible: "no"}, got2)
+
+	require.Panics(t, func() {
+		NewFuncChain().AddCoercer(func(_ string) coercedColor { return 0 })
+	})
+	require.Panics(t, func() {
+		NewFuncChain().AddCoercer(func(_ *string) (coercedColor, error) { return 0, nil })
+	})
+	require.Panics(t, func() {
+		NewFuncChain().AddCoercer(strconv.Atoi, strconv.Atoi)
+	})
+	require.Panics(t, func() {
+		NewFuncChain().Freeze().AddCoercer(strconv.Atoi)
+	})
+}
+
+type licenseName string
+
+type licenseExpr struct {
+	Names []string
+}
+
+type tagList []string
+
+type tagSet map[string]bool
+
+type tagCount int
+
+func Test_FuncChainNonStructConverters(t *testing.T) {
+	type from struct {
+		License  licenseName
+		Licenses []licenseName
+		Tags     map[string]tagList
+	}
+	type to struct {
+		License  licenseExpr
+		Licenses []*licenseExpr
+		Tags     map[string]tagCount
+	}
+
+	chain := NewFuncChain(
+		func(from licenseName, to *licenseExpr) {
+			to.Names = strings.Split(string(from), " OR ")
+		},
+		func(from tagList, to *tagSet) {
+			*to = tagSet{}
+			for _, tag := range from {
+				(*to)[tag] = true
+			}
+		},
+		// only reachable through tagSet
+		func(from tagSet, to *tagCount) error {
+			if len(from) == 0 {
+				return fmt.Errorf("no tags")
+			}
+			*to = tagCount(len(from))
+			return nil
+		},
+	).AllowImplicit()
+
+	got := to{}
+	require.NoError(t, chain.Convert(from{
+		License:  "MIT OR Apache-2.0",
+		Licenses: []licenseName{"MIT"},
+		Tags:     map[string]tagList{"a": {"x", "y", "x"}},
+	}, &got))
+	require.Equal(t, to{
+		License:  licenseExpr{Names: []string{"MIT", "Apache-2.0"}},
+		Licenses: []*licenseExpr{{Names: []string{"MIT"}}},
+		Tags:     map[string]tagCount{"a": 2},
+	}, got)
+
+	err := chain.Convert(from{Tags: map[string]tagList{"empty": {}}}, &to{})
+	require.ErrorContains(t, err, `converting Tags["empty"] from converter.tagSet to converter.tagCount (step 0)`)
+	require.ErrorContains(t, err, "no tags")
+
+	// the top level value is converted the same way
+	var count tagCount
+	require.NoError(t, chain.Convert(tagList{"a"}, &count))
+	require.Equal(t, tagCount(1), count)
+}
+
+func Test_FuncChainAutoPackageNonStructValues(t *testing.T) {
+	chain := NewFuncChain().AutoPackageConverter(v1.Package{}, v2.Package{})
+
+	// the types connected by AutoPackageConverter keep their values
+	got := v2.Package{}
+	require.NoError(t, chain.Convert(v1.Package{
+		Name:    "package",
+		License: "MIT",
+		Tags:    v1.Tags{"a", "b"},
+	}, &got))
+	require.Equal(t, v2.Package{
+		Name:    "package",
+		License: "MIT",
+		Tags:    v2.Tags{"a", "b"},
+	}, got)
+
+	type to struct {
+		License v2.License
+		Tags    tagSet
+	}
+	chain = NewFuncChain(func(from v2.Tags, to *tagSet) {
+		*to = tagSet{}
+		for _, tag := range from {
+			(*to)[tag] = true
+		}
+	}).AutoPackageConverter(v1.Package{}, v2.Package{}).AllowImplicit()
+
+	// v1.Tags are converted to v2.Tags, then by the converter
+	got2 := to{}
+	require.NoError(t, chain.Convert(v1.Package{License: "MIT", Tags: v1.Tags{"a"}}, &got2))
+	require.Equal(t, to{License: "MIT", Tags: tagSet{"a": true}}, got2)
+}
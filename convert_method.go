@@ -0,0 +1,108 @@
+package converter
+
+import (
+	"reflect"
+)
+
+// addConvertMethods registers conversions for ConvertFrom and ConvertTo methods declared on the type, in the forms:
+//
+//	func (t *Type2) ConvertFrom(from Type1) error
+//	func (t *Type2) ConvertFrom(chain FuncChain, from Type1) error
+//	func (t Type1) ConvertTo(to *Type2) error
+//	func (t Type1) ConvertTo(chain FuncChain, to *Type2) error
+//
+// any types referenced by these methods are also inspected, so the whole graph of versions reachable through methods
+// is registered. Explicitly added converters take precedence over methods. The chain must be locked for writing.
+func (c *funcChain) addConvertMethods(typ reflect.Type) {
+	typ = baseType(typ)
+	if c.inspected[typ] {
+		return
+	}
+	c.inspected[typ] = true
+
+	ptrType := reflect.PointerTo(typ)
+
+	if method, ok := ptrType.MethodByName(convertFromName); ok {
+		if fromType, hasChainParam, ok := convertMethodArg(method); ok && baseType(fromType) != typ {
+			c.addConvertMethod(fromType, ptrType, method, hasChainParam, true)
+			c.addConvertMethods(fromType)
+		}
+	}
+
+	if method, ok := ptrType.MethodByName(convertToName); ok {
+		if toType, hasChainParam, ok := convertMethodArg(method); ok && isPtr(toType) && baseType(toType) != typ {
+			c.addConvertMethod(typ, toType, method, hasChainParam, false)
+			c.addConvertMethods(toType)
+		}
+	}
+}
+
+func (c *funcChain) addConvertMethod(fromType, toType reflect.Type, method reflect.Method, hasChainParam, isConvertFrom bool) {
+	// methods only replace the implicit conversions between versions, see NewVersionedChain
+	if existing, exists := c.funcs[baseType(fromType)][baseType(toType)]; exists && (existing.origin == nil || !existing.origin.neighbor) {
+		return
+	}
+
+	argType := method.Type.In(method.Type.NumIn() - 1)
+	receiverType := method.Type.In(0)
+	returnsError := method.Type.NumOut() > 0
+
+	origin := &convertOrigin{
+		method:        &method,
+		isConvertFrom: isConvertFrom,
+		hasChainParam: hasChainParam,
+	}
+
+	c.addConvertFunc(fromType, toType, defaultCost, origin, func(from reflect.Value, to reflect.Value) error {
+		receiver, arg := from, to
+		if isConvertFrom {
+			receiver, arg = to, from
+		}
+
+		args := []reflect.Value{asArg(receiver, receiverType)}
+		if hasChainParam {
+			args = append(args, reflect.ValueOf(c))
+		}
+		args = append(args, asArg(arg, argType))
+
+		out := method.Func.Call(args)
+
+		if returnsError && !out[0].IsNil() {
+			return out[0].Interface().(error)
+		}
+		return nil
+	})
+}
+
+// convertMethodArg validates the method signature, returning the type of the converted argument and whether the
+// method accepts a FuncChain
+func convertMethodArg(method reflect.Method) (reflect.Type, bool, bool) {
+	t := method.Type
+	// the receiver is the first argument
+	switch {
+	case t.NumIn() == 2:
+	case t.NumIn() == 3 && t.In(1) == chainType:
+	default:
+		return nil, false, false
+	}
+	if t.NumOut() > 1 || (t.NumOut() == 1 && !t.Out(0).Implements(errorInterface)) {
+		return nil, false, false
+	}
+	return t.In(t.NumIn() - 1), t.NumIn() == 3, true
+}
+
+// asArg adapts the value to be passed as an argument of the given type, adding or removing a level of pointer
+func asArg(v reflect.Value, t reflect.Type) reflect.Value {
+	switch {
+	case v.Type() == t:
+		return v
+	case isPtr(t) && !isPtr(v.Type()):
+		if v.CanAddr() {
+			return v.Addr()
+		}
+		return toPtr(v)
+	case !isPtr(t) && isPtr(v.Type()):
+		return v.Elem()
+	}
+	return v
+}
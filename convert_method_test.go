@@ -0,0 +1,120 @@
+package converter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConvertMethods(t *testing.T) {
+	chain := NewFuncChain()
+
+	from := mv1{
+		Name:     "the name",
+		OldField: "the value",
+	}
+
+	// mv1 -> mv2 uses mv2.ConvertFrom, mv2 -> mv3 uses mv2.ConvertTo
+	to := mv3{}
+	err := chain.Convert(from, &to)
+	require.NoError(t, err)
+	require.Equal(t, mv3{
+		Name:       []string{"the name"},
+		FinalField: []string{"the value"},
+	}, to)
+
+	// mv3 -> mv2 uses mv3.ConvertTo, which receives the chain
+	back := mv2{}
+	err = chain.Convert(to, &back)
+	require.NoError(t, err)
+	require.Equal(t, mv2{
+		Name:     "the name",
+		NewField: "the value",
+	}, back)
+}
+
+func Test_ConvertMethodsNested(t *testing.T) {
+	type doc1 struct {
+		Items []mv1
+	}
+	type doc2 struct {
+		Items []mv2
+	}
+
+	to := doc2{}
+	err := NewFuncChain().AllowImplicit().Convert(doc1{Items: []mv1{{Name: "a", OldField: "b"}}}, &to)
+	require.NoError(t, err)
+	require.Equal(t, doc2{Items: []mv2{{Name: "a", NewField: "b"}}}, to)
+}
+
+func Test_ConvertMethodsExplicitConverterPrecedence(t *testing.T) {
+	chain := NewFuncChain(func(from mv1, to *mv2) {
+		to.NewField = "explicit: " + from.OldField
+	})
+
+	to := mv2{}
+	err := chain.Convert(mv1{OldField: "value"}, &to)
+	require.NoError(t, err)
+	require.Equal(t, "explicit: value", to.NewField)
+}
+
+func Test_ConvertMethodsExplicitConverterAfterDiscovery(t *testing.T) {
+	chain := NewFuncChain()
+
+	to := mv2{}
+	require.NoError(t, chain.Convert(mv1{OldField: "value"}, &to))
+	require.Equal(t, "value", to.NewField)
+
+	// converters added after the method was discovered still take precedence
+	chain.AddConverter(func(from mv1, to *mv2) {
+		to.NewField = "explicit: " + from.OldField
+	})
+	to = mv2{}
+	require.NoError(t, chain.Convert(mv1{OldField: "value"}, &to))
+	require.Equal(t, "explicit: value", to.NewField)
+
+	require.Panics(t, func() {
+		chain.AddConverter(func(from mv1, to *mv2) {})
+	})
+}
+
+func Test_ConvertMethodsError(t *testing.T) {
+	err := NewFuncChain().Convert(mv1{OldField: "fail"}, &mv2{})
+	require.ErrorIs(t, err, errMethodFailed)
+}
+
+var errMethodFailed = errors.New("method failed")
+
+type mv1 struct {
+	Name     string
+	OldField string
+}
+
+type mv2 struct {
+	Name     string
+	NewField string
+}
+
+func (m *mv2) ConvertFrom(from mv1) error {
+	if from.OldField == "fail" {
+		return errMethodFailed
+	}
+	m.NewField = from.OldField
+	return nil
+}
+
+func (m mv2) ConvertTo(to *mv3) error {
+	to.FinalField = []string{m.NewField}
+	return nil
+}
+
+type mv3 struct {
+	Name       []string
+	FinalField []string
+}
+
+func (m mv3) ConvertTo(_ FuncChain, to *mv2) error {
+	to.NewField = m.FinalField[0]
+	return nil
+}
@@ -3,20 +3,78 @@ package converter
 import (
 	"fmt"
 	"reflect"
-	"strconv"
 )
 
 type conversion struct {
-	errors []error
-	chain  *funcChain
+	convertOptions
+	errors  []error
+	chain   *funcChain
+	path    fieldPath
+	hop     int
+	visited map[visitKey]reflect.Value
+	// coercers are those registered with the chain when the conversion started
+	coercers map[typePair]reflect.Value
+	// fieldEncodings are the byte encodings set by the struct tags of the field being converted
+	fieldEncodings byteEncodings
 }
 
-func (c *conversion) err(err error) {
-	c.errors = append(c.errors, err)
+// visitKey identifies a source pointer being converted to a target type; the same pointer may be converted to
+// different types, and pointers to a struct and its first field share an address, so the types are included
+type visitKey struct {
+	addr     uintptr
+	fromType reflect.Type
+	toType   reflect.Type
 }
 
-func (c *conversion) errf(format string, args ...any) {
-	c.err(fmt.Errorf(format, args...))
+func (c *conversion) err(fromType, toType reflect.Type, err error) {
+	c.errors = append(c.errors, &ConversionError{
+		Path: c.path.String(),
+		From: fromType,
+		To:   toType,
+		Hop:  c.hop,
+		Err:  err,
+	})
+}
+
+func (c *conversion) errf(fromType, toType reflect.Type, format string, args ...any) {
+	c.err(fromType, toType, fmt.Errorf(format, args...))
+}
+
+// stopped indicates no more values should be converted, because an error occurred when failing fast
+func (c *conversion) stopped() bool {
+	return c.failFast && len(c.errors) > 0
+}
+
+// tracksDropped indicates whether source data which is not converted needs to be found
+func (c *conversion) tracksDropped() bool {
+	return c.strict || c.report != nil
+}
+
+// dropped records source data at the current path which was not converted
+func (c *conversion) dropped(fromType, toType reflect.Type, reason error) {
+	c.warn(reason)
+	if c.strict {
+		c.err(fromType, toType, reason)
+	}
+}
+
+// warn adds an entry to the report for the current path, if there is one
+func (c *conversion) warn(reason error) {
+	if c.report != nil {
+		c.report.Entries = append(c.report.Entries, ReportEntry{
+			Path:   c.path.String(),
+			Reason: reason,
+		})
+	}
+}
+
+// exceedsMaxDepth reports an error when values nested within the current value would be deeper than the maximum
+func (c *conversion) exceedsMaxDepth(fromType, toType reflect.Type) bool {
+	if c.maxDepth > 0 && len(c.path) >= c.maxDepth {
+		c.errf(fromType, toType, "maximum depth of %d exceeded", c.maxDepth)
+		return true
+	}
+	return false
 }
 
 // Convert takes two objects, e.g. v2_1.Document and &v2_2.Document{} and attempts to map all the properties from one
@@ -26,7 +84,13 @@ func (c *conversion) convert(fromValue reflect.Value, toValuePtr reflect.Value)
 	toTypePtr := toValuePtr.Type()
 
 	if !isPtr(toTypePtr) {
-		c.errf("TO value provided was not a pointer, unable to set value: %+v", toValuePtr)
+		c.errf(fromValue.Type(), toTypePtr, "TO value provided was not a pointer, unable to set value: %+v", toValuePtr)
+		return
+	}
+
+	// a pointer being converted may be referenced by values within it, these should refer to the provided target
+	if isPtr(fromValue.Type()) && !isPtr(toTypePtr.Elem()) {
+		c.getPointerValue(fromValue, toTypePtr, toValuePtr)
 		return
 	}
 
@@ -42,10 +106,21 @@ func (c *conversion) convert(fromValue reflect.Value, toValuePtr reflect.Value)
 }
 
 func (c *conversion) getValue(fromValue reflect.Value, targetType reflect.Type) reflect.Value {
+	if c.stopped() {
+		return nilValue
+	}
 	fromType := fromValue.Type()
 
+	// values held by interfaces are converted by their concrete type
+	if isInterface(fromType) {
+		return c.getDynamicValue(fromValue, targetType)
+	}
+
 	// handle incoming pointer types
 	if isPtr(fromType) {
+		if !isPtr(fromType.Elem()) {
+			return c.getPointerValue(fromValue, targetType, nilValue)
+		}
 		if fromValue.IsNil() {
 			return nilValue
 		}
@@ -67,12 +142,21 @@ func (c *conversion) getValue(fromValue reflect.Value, targetType reflect.Type)
 		return nilValue
 	}
 
-	// handle non-pointer returns -- the reflect.New earlier always creates a pointer
-	if !isPtr(baseTargetType) {
+	// handle non-pointer returns -- the reflect.New earlier always creates a pointer; an interface may be implemented
+	// by a pointer
+	if !isPtr(baseTargetType) && !isInterface(baseTargetType) {
 		toValue = fromPtr(toValue)
 	}
 
-	toValue = c.convertValueTypes(toValue, baseTargetType)
+	// values converted to an interface they implement, such as the values of a map[string]any, are kept as they are
+	if isInterface(baseTargetType) && toValue.Type().AssignableTo(baseTargetType) {
+		toValue = toValue.Convert(baseTargetType)
+	} else {
+		toValue = c.convertValueTypes(toValue, baseTargetType)
+	}
+	if !toValue.IsValid() {
+		return nilValue
+	}
 
 	// handle elements which are now pointers
 	if isPtr(targetType) {
@@ -82,45 +166,158 @@ func (c *conversion) getValue(fromValue reflect.Value, targetType reflect.Type)
 	return toValue
 }
 
+// getDynamicValue converts the value held by an interface by its concrete type
+func (c *conversion) getDynamicValue(fromValue reflect.Value, targetType reflect.Type) reflect.Value {
+	value := fromValue.Elem()
+	if !value.IsValid() {
+		return nilValue
+	}
+	return c.getRoutedValue(value, targetType)
+}
+
+// getRoutedValue converts a value whose type is not known to be converted to the target type by the types it is nested
+// in, such as a value held by an interface. Structs are converted following the registered conversions to the target
+// type when there are any, the same as at the top level.
+func (c *conversion) getRoutedValue(value reflect.Value, targetType reflect.Type) reflect.Value {
+	baseTargetType := targetType
+	if isPtr(targetType) {
+		baseTargetType = targetType.Elem()
+	}
+	valueType := baseType(value.Type())
+	if valueType == baseTargetType || !isStruct(valueType) || !isStruct(baseTargetType) || !c.hasRoute(valueType, baseTargetType) {
+		return c.getValue(value, targetType)
+	}
+
+	for isPtr(value.Type()) {
+		if value.IsNil() {
+			return nilValue
+		}
+		value = value.Elem()
+	}
+	toValue := c.getRouteValue(value, valueType, baseTargetType)
+	if toValue == nilValue || !isPtr(targetType) {
+		return toValue
+	}
+	return toPtr(toValue)
+}
+
+// getPointerValue converts the value a source pointer refers to, keeping track of the pointers visited so that a
+// pointer referenced multiple times is converted to a single target pointer, and cycles are converted to cycles rather
+// than recursing indefinitely. If target is valid, it is the pointer to convert to.
+func (c *conversion) getPointerValue(fromValue reflect.Value, targetType reflect.Type, target reflect.Value) reflect.Value {
+	if fromValue.IsNil() {
+		return nilValue
+	}
+	elem := fromValue.Elem()
+	// coercers are called for every value, including zero values
+	if elem.IsZero() && !c.preserveZeroValues && !c.hasCoercer(elem.Type(), baseType(targetType)) {
+		return nilValue
+	}
+
+	if c.visited == nil {
+		c.visited = map[visitKey]reflect.Value{}
+	}
+	key := visitKey{fromValue.Pointer(), fromValue.Type(), targetType}
+	if existing, ok := c.visited[key]; ok {
+		if !existing.IsValid() {
+			c.errf(fromValue.Type(), targetType, "cycle detected, a pointer refers back to itself and %s is not a pointer", nameOf(targetType))
+		}
+		return existing
+	}
+
+	if !isPtr(targetType) || isPtr(targetType.Elem()) {
+		// values are copied, so only need to be tracked while converting to detect cycles
+		c.visited[key] = nilValue
+		defer delete(c.visited, key)
+		return c.getValue(elem, targetType)
+	}
+
+	// the target pointer must be known before converting any values which may refer back to it
+	if !target.IsValid() {
+		target = reflect.New(targetType.Elem())
+	}
+	c.visited[key] = target
+
+	toValue := c.getValue(elem, targetType.Elem())
+	if toValue == nilValue {
+		delete(c.visited, key)
+		return nilValue
+	}
+	target.Elem().Set(toValue)
+	return target
+}
+
 // getValueByKind dispatches to the appropriate conversion method based on the kind of the types involved.
 func (c *conversion) getValueByKind(fromValue reflect.Value, fromType, baseTargetType reflect.Type) reflect.Value {
 	switch {
+	case c.hasCoercer(fromType, baseTargetType):
+		// converted by convertValueTypes
+		return fromValue
 	case isInterface(baseTargetType):
-		satisfyingType := c.findConvertableType(fromType, baseTargetType)
-		if satisfyingType != nil {
+		if impls, ok := c.chain.registeredImplementations(baseTargetType); ok {
+			implType, err := c.resolveImplementation(fromValue, baseTargetType, impls)
+			if err != nil {
+				c.err(fromType, baseTargetType, err)
+				return nilValue
+			}
+			return c.getRoutedValue(fromValue, implType)
+		}
+		satisfyingType := c.chain.resolveInterface(fromType, baseTargetType)
+		switch {
+		case satisfyingType != nil:
 			return c.getValue(fromValue, satisfyingType)
+		case fromType.AssignableTo(baseTargetType):
+			// kept as it is, by convertValueTypes
+			return fromValue
 		}
 		return nilValue
+	case fromType != baseTargetType && !(isStruct(fromType) && isStruct(baseTargetType)) && c.hasRoute(fromType, baseTargetType):
+		return c.getRouteValue(fromValue, fromType, baseTargetType)
+	case fromType == baseTargetType && isOpaque(fromType):
+		return fromValue
 	case isStruct(fromType) && isStruct(baseTargetType):
 		return c.getStructValue(fromValue, fromType, baseTargetType)
 	case isSlice(fromType) && isSlice(baseTargetType):
 		return c.getSliceValue(fromValue, baseTargetType)
 	case isMap(fromType) && isMap(baseTargetType):
 		return c.getMapValue(fromValue, baseTargetType)
+	case isStringMap(fromType) && isStruct(baseTargetType):
+		return c.getMapStructValue(fromValue, fromType, baseTargetType)
+	case isStruct(fromType) && isStringMap(baseTargetType):
+		return c.getStructMapValue(fromValue, fromType, baseTargetType)
 	default:
 		return fromValue
 	}
 }
 
-// getStructValue handles struct-to-struct conversion by mapping fields with matching names.
+// getStructValue handles struct-to-struct conversion by mapping fields with matching names, taking into account
+// any renames specified by struct tags.
 func (c *conversion) getStructValue(fromValue reflect.Value, fromType, baseTargetType reflect.Type) reflect.Value {
-	toValue := reflect.New(baseTargetType).Elem()
-
-	for i := 0; i < fromType.NumField(); i++ {
-		fromField := fromType.Field(i)
-		fromFieldValue := fromValue.Field(i)
+	if c.exceedsMaxDepth(fromType, baseTargetType) {
+		return nilValue
+	}
 
-		toField, exists := baseTargetType.FieldByName(fromField.Name)
-		if !exists {
-			continue
-		}
+	toValue := reflect.New(baseTargetType).Elem()
 
-		newValue := c.getValue(fromFieldValue, toField.Type)
+	plan := structPlan(fromType, baseTargetType, c.fieldMatching)
+	for _, m := range plan.mappings {
+		path, encodings := c.path, c.fieldEncodings
+		c.path, c.fieldEncodings = c.path.field(m.from.field.Name), m.encodings()
+		newValue := c.getValue(fromValue.FieldByIndex(m.from.field.Index), m.to.field.Type)
+		c.path, c.fieldEncodings = path, encodings
 		if newValue == nilValue {
 			continue
 		}
 
-		toValue.FieldByName(toField.Name).Set(newValue)
+		toValue.FieldByIndex(m.to.field.Index).Set(newValue)
+	}
+
+	if c.tracksDropped() {
+		c.unmappedSources(fromValue, baseTargetType, plan)
+	}
+
+	if c.stopped() {
+		return nilValue
 	}
 
 	// check for custom convert functions from previous/next version struct
@@ -128,12 +325,56 @@ func (c *conversion) getStructValue(fromValue reflect.Value, fromType, baseTarge
 		return value
 	}
 
+	if !c.callHooks(fromValue, fromType, baseTargetType, toValue) {
+		return nilValue
+	}
+
+	if c.unmappedTargets != UnmappedTargetIgnore {
+		c.checkUnmappedTargets(fromType, baseTargetType, toValue, plan)
+	}
+
 	return toValue
 }
 
+// unmappedSources records the non-zero source fields with no target field, unless there is a converter function, which
+// is expected to handle these
+func (c *conversion) unmappedSources(fromValue reflect.Value, baseTargetType reflect.Type, plan fieldPlan) {
+	if len(plan.unmappedSources) == 0 || c.chain.convertFunc(fromValue.Type(), baseTargetType) != nil {
+		return
+	}
+	path := c.path
+	for _, f := range plan.unmappedSources {
+		if fromValue.FieldByIndex(f.field.Index).IsZero() {
+			continue
+		}
+		c.path = path.field(f.field.Name)
+		c.dropped(f.field.Type, baseTargetType, ErrUnmappedField)
+	}
+	c.path = path
+}
+
+// checkUnmappedTargets warns about or reports errors for the target fields without a source field which were not set
+func (c *conversion) checkUnmappedTargets(fromType, baseTargetType reflect.Type, toValue reflect.Value, plan fieldPlan) {
+	path := c.path
+	for _, f := range plan.unmappedTargets {
+		if !toValue.FieldByIndex(f.field.Index).IsZero() {
+			continue
+		}
+		c.path = path.field(f.field.Name)
+		switch c.unmappedTargets {
+		case UnmappedTargetWarn:
+			c.warn(ErrUnmappedTarget)
+		case UnmappedTargetError:
+			c.err(fromType, baseTargetType, ErrUnmappedTarget)
+		default:
+		}
+	}
+	c.path = path
+}
+
 // getSliceValue handles slice-to-slice conversion by converting each element.
 func (c *conversion) getSliceValue(fromValue reflect.Value, baseTargetType reflect.Type) reflect.Value {
-	if fromValue.IsNil() {
+	if fromValue.IsNil() || c.exceedsMaxDepth(fromValue.Type(), baseTargetType) {
 		return nilValue
 	}
 
@@ -141,19 +382,22 @@ func (c *conversion) getSliceValue(fromValue reflect.Value, baseTargetType refle
 	targetElementType := baseTargetType.Elem()
 	toValue := reflect.MakeSlice(baseTargetType, length, length)
 
+	path := c.path
 	for i := range length {
+		c.path = path.index(i)
 		v := c.getValue(fromValue.Index(i), targetElementType)
 		if v.IsValid() {
 			toValue.Index(i).Set(v)
 		}
 	}
+	c.path = path
 
 	return toValue
 }
 
 // getMapValue handles map-to-map conversion by converting each key-value pair.
 func (c *conversion) getMapValue(fromValue reflect.Value, baseTargetType reflect.Type) reflect.Value {
-	if fromValue.IsNil() {
+	if fromValue.IsNil() || c.exceedsMaxDepth(fromValue.Type(), baseTargetType) {
 		return nilValue
 	}
 
@@ -161,34 +405,92 @@ func (c *conversion) getMapValue(fromValue reflect.Value, baseTargetType reflect
 	elementType := baseTargetType.Elem()
 	toValue := reflect.MakeMap(baseTargetType)
 
+	path := c.path
 	for _, fromKey := range fromValue.MapKeys() {
+		if c.stopped() {
+			break
+		}
+		c.path = path.key(fromKey)
 		fromVal := fromValue.MapIndex(fromKey)
+		errs := len(c.errors)
 		k := c.getValue(fromKey, keyType)
 		v := c.getValue(fromVal, elementType)
 
 		if k == nilValue || v == nilValue {
+			// entries which failed to convert are already reported as errors
+			if c.tracksDropped() && len(c.errors) == errs {
+				c.dropped(fromValue.Type(), baseTargetType, ErrDroppedEntry)
+			}
 			continue
 		}
 		if k.IsValid() && v.IsValid() {
 			toValue.SetMapIndex(k, v)
 		}
 	}
+	c.path = path
 
 	return toValue
 }
 
+// hasRoute indicates there are registered conversions between the types, which are needed to convert types other
+// than structs; structs are otherwise mapped field by field
+func (c *conversion) hasRoute(fromType, baseTargetType reflect.Type) bool {
+	c.chain.inspectTypes(fromType, baseTargetType)
+	return isRoute(c.chain.registeredRoute(fromType, baseTargetType), fromType, baseTargetType)
+}
+
+// getRouteValue converts a value by calling each of the registered conversions along the route between the types,
+// intermediate structs are mapped field by field before their conversion is called, the same as nested structs are
+func (c *conversion) getRouteValue(fromValue reflect.Value, fromType, baseTargetType reflect.Type) reflect.Value {
+	value := fromValue
+	for _, step := range c.chain.registeredRoute(fromType, baseTargetType) {
+		valueType := value.Type()
+		if isStruct(valueType) && isStruct(step.targetType) {
+			value = c.getStructValue(value, valueType, step.targetType)
+			if value == nilValue {
+				return nilValue
+			}
+			continue
+		}
+		if step.origin.isNoop() {
+			value = c.getValue(value, step.targetType)
+			if value == nilValue {
+				return nilValue
+			}
+			continue
+		}
+		toValue := reflect.New(step.targetType).Elem()
+		if _, failed := c.callConversionFunc(value, valueType, step.targetType, toValue); failed {
+			return nilValue
+		}
+		value = toValue
+	}
+	return value
+}
+
 func (c *conversion) callConversionFunc(fromValue reflect.Value, fromType, baseTargetType reflect.Type, toValue reflect.Value) (reflect.Value, bool) {
-	if c.chain.funcs[fromType] != nil && c.chain.funcs[fromType][baseTargetType] != nil {
-		convertFunc := c.chain.funcs[fromType][baseTargetType]
+	c.chain.inspectTypes(fromType, baseTargetType)
+	if convertFunc := c.chain.convertFunc(fromType, baseTargetType); convertFunc != nil {
 		err := convertFunc(fromValue, toValue.Addr())
 		if err != nil {
-			c.errf("an error occurred calling %s.%s: %v", baseTargetType.Name(), convertFromName, err)
+			c.errf(fromType, baseTargetType, "an error occurred calling %s.%s: %w", baseTargetType.Name(), convertFromName, err)
 			return nilValue, true
 		}
 	}
 	return reflect.Value{}, false
 }
 
+// callHooks calls the hooks with a converted struct value, returning false if any of them fail
+func (c *conversion) callHooks(fromValue reflect.Value, fromType, baseTargetType reflect.Type, toValue reflect.Value) bool {
+	for _, hook := range c.hooks {
+		if err := hook(c.path.String(), fromValue, toValue.Addr()); err != nil {
+			c.err(fromType, baseTargetType, err)
+			return false
+		}
+	}
+	return true
+}
+
 // convertValueTypes takes a value and a target type, and attempts to convert
 // between the Types - e.g. string -> int. when this function is called the value
 func (c *conversion) convertValueTypes(value reflect.Value, targetType reflect.Type) reflect.Value {
@@ -197,47 +499,40 @@ func (c *conversion) convertValueTypes(value reflect.Value, targetType reflect.T
 	// if the Types are the same, just return the value
 	case typ == targetType:
 		return value
+	case c.hasCoercer(typ, targetType):
+		return c.coerce(value, targetType)
+	case c.isTime(typ, targetType):
+		if value.IsZero() {
+			return c.zero(targetType)
+		}
+		v, err := c.convertTime(value, targetType)
+		return c.coerced(typ, targetType, v, err)
 	case typ.Kind() == targetType.Kind() && typ.ConvertibleTo(targetType):
 		return value.Convert(targetType)
-	case value.IsZero() && isPrimitive(targetType):
-		// do nothing, will return nilValue
+	case value.IsZero() && (isPrimitive(targetType) || c.isText(typ, targetType) || isByteString(typ, targetType)) &&
+		(!c.preserveZeroValues || isString(typ)):
+		// when preserved, zero values other than empty strings are converted like any other value, e.g. false to "false"
+		return c.zero(targetType)
+	case c.isText(typ, targetType):
+		return c.convertText(value, targetType)
+	case isByteString(typ, targetType):
+		v, err := c.convertByteString(value, targetType)
+		return c.coerced(typ, targetType, v, err)
 	case isPrimitive(typ) && isPrimitive(targetType):
-		// get a string representation of the value
-		str := fmt.Sprintf("%v", value.Interface()) // TODO is there a better way to get a string representation?
-		var err error
-		var out any
-		switch {
-		case isString(targetType):
-			out = str
-		case isBool(targetType):
-			out, err = strconv.ParseBool(str)
-		case isInt(targetType):
-			out, err = strconv.Atoi(str)
-		case isUint(targetType):
-			out, err = strconv.ParseUint(str, 10, 64)
-		case isFloat(targetType):
-			out, err = strconv.ParseFloat(str, 64)
-		}
-
-		if err != nil {
-			c.err(err)
-			return nilValue
-		}
-
-		v := reflect.ValueOf(out)
-
-		v = v.Convert(targetType)
-
-		return v
+		v, err := getPrimitiveCoercer(typ, targetType)(value)
+		return c.coerced(typ, targetType, v, err)
 	case isSlice(typ) && isSlice(targetType):
 		// this should already be handled in getValue
 	case isSlice(typ):
 		// this may be lossy
+		if value.Len() > 1 && c.tracksDropped() {
+			c.dropped(typ, targetType, fmt.Errorf("%w: %d of %d elements dropped", ErrLossyConversion, value.Len()-1, value.Len()))
+		}
 		if value.Len() > 0 {
 			v := value.Index(0)
 			return c.convertValueTypes(v, targetType)
 		}
-		return c.convertValueTypes(nilValue, targetType)
+		return nilValue
 	case isSlice(targetType):
 		elementType := targetType.Elem()
 		v := c.convertValueTypes(value, elementType)
@@ -249,41 +544,45 @@ func (c *conversion) convertValueTypes(value reflect.Value, targetType reflect.T
 		return slice
 	}
 
-	c.errf("unable to convert from: %v to %v", value.Interface(), targetType.Name())
+	c.errf(typ, targetType, "unable to convert from: %v to %v", value.Interface(), targetType.Name())
 	return nilValue
 }
 
-func (c *conversion) findConvertableType(fromType reflect.Type, targetType reflect.Type) reflect.Type {
-	converters := c.chain.funcs[fromType]
-	if converters == nil {
-		return nil
+// zero returns the value a zero value is converted to, which is not set unless preserving zero values
+func (c *conversion) zero(targetType reflect.Type) reflect.Value {
+	if c.preserveZeroValues {
+		return reflect.Zero(targetType)
 	}
-	if v, ok := converters[targetType]; ok {
-		if v == nil {
-			return nil
-		}
-		return targetType
-	}
-	var found *reflect.Type
-	for target := range converters {
-		if target.AssignableTo(targetType) {
-			if found == nil {
-				found = &target
-			} else {
-				// found multiple
-				found = nil
-				break
-			}
-		}
+	return nilValue
+}
+
+// coerced returns a converted value, or reports the error converting it; values which overflow or lose precision are
+// kept with a warning when using lenient numbers
+func (c *conversion) coerced(fromType, toType reflect.Type, value reflect.Value, err error) reflect.Value {
+	switch {
+	case err != nil && c.lenientNumbers && isLossy(err) && value.IsValid():
+		c.warn(err)
+	case err != nil:
+		c.err(fromType, toType, err)
+		return nilValue
 	}
+	return value
+}
 
-	if found == nil {
-		// if we didn't find exactly 1, don't check again
-		converters[targetType] = nil
-		return nil
+// hasCoercer indicates a coercer is registered to convert values directly between the types
+func (c *conversion) hasCoercer(fromType, toType reflect.Type) bool {
+	_, ok := c.coercers[typePair{fromType, toType}]
+	return ok
+}
+
+// coerce converts the value with the registered coercer, reporting any error it returns
+func (c *conversion) coerce(value reflect.Value, targetType reflect.Type) reflect.Value {
+	out := c.coercers[typePair{value.Type(), targetType}].Call([]reflect.Value{value})
+	if err, _ := out[1].Interface().(error); err != nil {
+		c.err(value.Type(), targetType, err)
+		return nilValue
 	}
-	converters[targetType] = converters[*found]
-	return *found
+	return out[0]
 }
 
 func isPtr(typ reflect.Type) bool {
@@ -321,7 +620,8 @@ func isUint(typ reflect.Type) bool {
 		reflect.Uint8,
 		reflect.Uint16,
 		reflect.Uint32,
-		reflect.Uint64:
+		reflect.Uint64,
+		reflect.Uintptr:
 		return true
 	default:
 		return false
@@ -338,10 +638,28 @@ func isFloat(typ reflect.Type) bool {
 	}
 }
 
+func isNumber(typ reflect.Type) bool {
+	return isInt(typ) || isUint(typ) || isFloat(typ)
+}
+
 func isStruct(typ reflect.Type) bool {
 	return typ.Kind() == reflect.Struct
 }
 
+// isOpaque indicates the type is a struct without exported fields, such as time.Time, so values are not able to be
+// converted field by field and are copied as they are to the same type
+func isOpaque(typ reflect.Type) bool {
+	if !isStruct(typ) {
+		return false
+	}
+	for i := range typ.NumField() {
+		if typ.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
 func isSlice(typ reflect.Type) bool {
 	return typ.Kind() == reflect.Slice
 }
@@ -375,6 +693,9 @@ func fromPtr(val reflect.Value) reflect.Value {
 // convertFromName constant to find the ConvertFrom method
 const convertFromName = "ConvertFrom"
 
+// convertToName constant to find the ConvertTo method
+const convertToName = "ConvertTo"
+
 var (
 	// nilValue is returned in a number of cases when a value should not be set
 	nilValue = reflect.ValueOf(nil)
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_ConvertWithKnownTypes(t *testing.T) {
@@ -533,6 +534,17 @@ func Test_Convert(t *testing.T) {
 				Value: "thing 1",
 			},
 		},
+		{
+			name: "empty string slice to string",
+			from: struct {
+				Value []string
+			}{
+				Value: []string{},
+			},
+			to: struct {
+				Value string
+			}{},
+		},
 		{
 			name: "map string to string slice",
 			from: struct {
@@ -701,3 +713,136 @@ func t5_t6(t5 T5, t6 *T6) error {
 	t6.Version = "2.3"
 	return nil
 }
+
+type nodeV1 struct {
+	Name     string
+	Parent   *nodeV1
+	Children []*nodeV1
+}
+
+type nodeV2 struct {
+	Name     string
+	Parent   *nodeV2
+	Children []*nodeV2
+}
+
+type nodeValueV2 struct {
+	Name     string
+	Children []nodeValueV2
+}
+
+func Test_ConvertCycles(t *testing.T) {
+	root := &nodeV1{Name: "root"}
+	child := &nodeV1{Name: "child", Parent: root}
+	root.Children = []*nodeV1{child, child}
+
+	chain := NewFuncChain().AllowImplicit()
+
+	got := nodeV2{}
+	err := chain.Convert(root, &got)
+	require.NoError(t, err)
+	require.Equal(t, "root", got.Name)
+	require.Len(t, got.Children, 2)
+	require.Equal(t, "child", got.Children[0].Name)
+	// a shared pointer is converted to a single target pointer
+	require.Same(t, got.Children[0], got.Children[1])
+	// the cycle refers back to the value provided to convert to
+	require.Same(t, &got, got.Children[0].Parent)
+
+	// the same is true when converting from a value rather than a pointer
+	got = nodeV2{}
+	err = chain.Convert(*child, &got)
+	require.NoError(t, err)
+	require.Equal(t, "child", got.Name)
+	require.Same(t, got.Parent.Children[0], got.Parent.Children[1])
+
+	// cycles which are unable to be represented are errors rather than recursing indefinitely
+	child.Children = []*nodeV1{root}
+	err = chain.Convert(root, &nodeValueV2{})
+	require.ErrorContains(t, err, "cycle detected")
+}
+
+func Test_ConvertPreserveZeroValues(t *testing.T) {
+	type inner struct {
+		Value string
+	}
+	type from struct {
+		Enabled *bool
+		Count   *int
+		Inner   *inner
+		Name    string
+		Size    int
+		Missing *string
+		Label   string
+	}
+	type to struct {
+		Enabled *bool
+		Count   *string
+		Inner   *inner
+		Name    string
+		Size    string
+		Missing *string
+		Label   int
+	}
+
+	f := false
+	zero := 0
+	value := from{
+		Enabled: &f,
+		Count:   &zero,
+		Inner:   &inner{},
+		Size:    0,
+	}
+
+	got := to{}
+	err := NewFuncChain().AllowImplicit().Convert(value, &got)
+	require.NoError(t, err)
+	require.Nil(t, got.Enabled)
+	require.Nil(t, got.Count)
+	require.Nil(t, got.Inner)
+
+	got = to{}
+	err = NewFuncChain().AllowImplicit().PreserveZeroValues().Convert(value, &got)
+	require.NoError(t, err)
+	require.Equal(t, &f, got.Enabled)
+	require.Equal(t, ptr("0"), got.Count)
+	require.Equal(t, &inner{}, got.Inner)
+	require.Equal(t, "0", got.Size)
+	require.Nil(t, got.Missing)
+	require.Equal(t, 0, got.Label)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func Test_ConvertDynamicValues(t *testing.T) {
+	type from struct {
+		Items  []any
+		Values map[string]any
+		Single any
+	}
+	type to struct {
+		Items  []*t3
+		Values map[string]int
+		Single t3
+	}
+
+	chain := NewFuncChain(t1ToT2, t2ToT3).AllowImplicit()
+
+	got := to{}
+	require.NoError(t, chain.Convert(from{
+		// the registered conversions are followed for each concrete type
+		Items:  []any{t1{Name: "a", Custom1: "1"}, &t2{Name: "b", Custom2: "2"}, t3{Name: "c"}, nil},
+		Values: map[string]any{"a": 1, "b": "2", "c": 3.0},
+		Single: t1{Name: "single", Custom1: "custom"},
+	}, &got))
+	require.Equal(t, to{
+		Items:  []*t3{{Name: "a", Custom3: "1"}, {Name: "b", Custom3: "2"}, {Name: "c"}, nil},
+		Values: map[string]int{"a": 1, "b": 2, "c": 3},
+		Single: t3{Name: "single", Custom3: "custom"},
+	}, got)
+
+	err := chain.Convert(from{Values: map[string]any{"a": 1, "b": "b"}}, &to{})
+	require.ErrorContains(t, err, `converting Values["b"] from string to int (step 0)`)
+}
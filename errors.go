@@ -0,0 +1,132 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ConversionError is returned for every failure encountered while converting a value, describing where in the
+// object graph the failure happened, e.g. Packages[12].Files["a"].Size, the types involved, which step of the
+// conversion chain was executing and the underlying cause. Use errors.As to extract these from the result of Convert.
+type ConversionError struct {
+	// Path is the field path from the root value to the value which failed to convert, empty for the root value
+	Path string
+	// From is the type of the source value
+	From reflect.Type
+	// To is the type the value was being converted to
+	To reflect.Type
+	// Hop is the index of the step in the conversion chain being executed when the error occurred
+	Hop int
+	// Err is the underlying cause
+	Err error
+}
+
+func (e *ConversionError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("converting ")
+	if e.Path != "" {
+		sb.WriteString(e.Path)
+		sb.WriteString(" ")
+	}
+	fmt.Fprintf(&sb, "from %s to %s (step %d): %v", nameOf(e.From), nameOf(e.To), e.Hop, e.Err)
+	return sb.String()
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	// ErrUnmappedField indicates a source struct field has no target field, and the source and target types have no
+	// converter function which may have used it
+	ErrUnmappedField = errors.New("source field has no target field")
+	// ErrDroppedEntry indicates a map entry was not converted, since its key or value was converted to nothing
+	ErrDroppedEntry = errors.New("map entry dropped")
+	// ErrLossyConversion indicates a slice was converted to a single value, keeping only the first element
+	ErrLossyConversion = errors.New("lossy conversion of slice to a single value")
+	// ErrUnmappedTarget indicates a target struct field has no source field and was not set by a converter function
+	// or hook, see WithUnmappedTargets
+	ErrUnmappedTarget = errors.New("target field was not set")
+	// ErrOverflow indicates a number is outside the range of the type it is converted to, see WithLenientNumbers
+	ErrOverflow = errors.New("number overflows type")
+	// ErrPrecisionLoss indicates a number is not able to be represented exactly by the floating point type it is
+	// converted to, see WithLenientNumbers
+	ErrPrecisionLoss = errors.New("number loses precision")
+	// ErrNoImplementation indicates a value is not able to be converted to any of the implementations registered for
+	// an interface, see FuncChain.RegisterImplementations
+	ErrNoImplementation = errors.New("no implementation of interface")
+	// ErrAmbiguousImplementation indicates a value is able to be converted to more than one of the implementations
+	// registered for an interface, and the discriminator did not choose one
+	ErrAmbiguousImplementation = errors.New("ambiguous implementation of interface")
+)
+
+// Report lists the data from a source value which was not converted, this includes non-zero source fields with no
+// target field, map entries which were dropped and slices with multiple elements converted to a single value.
+// Source fields tagged to be ignored are not included. Warnings are also reported here, such as target fields which
+// were not set when using WithUnmappedTargets and numbers converted inexactly when using WithLenientNumbers.
+type Report struct {
+	Entries []ReportEntry
+}
+
+// ReportEntry is an item of source data which was not converted
+type ReportEntry struct {
+	// Path is the field path from the root value to the source value which was not converted
+	Path string
+	// Reason is why the value was not converted, or the warning, one of: ErrUnmappedField, ErrDroppedEntry,
+	// ErrLossyConversion, ErrUnmappedTarget, ErrOverflow or ErrPrecisionLoss, possibly wrapped with more detail
+	Reason error
+}
+
+// fieldPath tracks the location in the object graph currently being converted, the elements are only formatted when
+// needed, since this is tracked for every value converted
+type fieldPath []pathElement
+
+// pathElement is one of: a struct field name, a slice index or a map key
+type pathElement struct {
+	name  string
+	index int
+	key   reflect.Value
+}
+
+func (p fieldPath) String() string {
+	var sb strings.Builder
+	for i, e := range p {
+		switch {
+		case e.name != "":
+			if i > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(e.name)
+		case e.key.IsValid() && e.key.Kind() == reflect.String:
+			sb.WriteString("[" + strconv.Quote(e.key.String()) + "]")
+		case e.key.IsValid():
+			fmt.Fprintf(&sb, "[%v]", e.key)
+		default:
+			sb.WriteString("[" + strconv.Itoa(e.index) + "]")
+		}
+	}
+	return sb.String()
+}
+
+func (p fieldPath) field(name string) fieldPath {
+	return append(p, pathElement{name: name})
+}
+
+func (p fieldPath) index(i int) fieldPath {
+	return append(p, pathElement{index: i})
+}
+
+func (p fieldPath) key(key reflect.Value) fieldPath {
+	return append(p, pathElement{key: key})
+}
+
+// nameOf returns a short, readable name for the type, e.g. string, []v2.Package, *v3.Document
+func nameOf(t reflect.Type) string {
+	if t == nil {
+		return "<nil>"
+	}
+	return t.String()
+}
@@ -0,0 +1,79 @@
+package converter
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConversionErrorPath(t *testing.T) {
+	type fileV1 struct {
+		Size string
+	}
+	type packageV1 struct {
+		Files map[string]fileV1
+	}
+	type documentV1 struct {
+		Packages []packageV1
+	}
+
+	type fileV2 struct {
+		Size int
+	}
+	type packageV2 struct {
+		Files map[string]fileV2
+	}
+	type documentV2 struct {
+		Packages []packageV2
+	}
+
+	from := documentV1{
+		Packages: []packageV1{
+			{Files: map[string]fileV1{"a": {Size: "12"}}},
+			{Files: map[string]fileV1{"b": {Size: "abc"}}},
+		},
+	}
+
+	to := documentV2{}
+	err := NewFuncChain().AllowImplicit().Convert(from, &to)
+	require.Error(t, err)
+
+	var convErr *ConversionError
+	require.True(t, errors.As(err, &convErr))
+	require.Equal(t, `Packages[1].Files["b"].Size`, convErr.Path)
+	require.Equal(t, reflect.TypeFor[string](), convErr.From)
+	require.Equal(t, reflect.TypeFor[int](), convErr.To)
+	require.Equal(t, 0, convErr.Hop)
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	require.ErrorContains(t, err, `Packages[1].Files["b"].Size from string to int`)
+
+	// values which converted successfully are still set
+	require.Equal(t, 12, to.Packages[0].Files["a"].Size)
+}
+
+func Test_ConversionErrorHop(t *testing.T) {
+	errCustom := errors.New("custom failure")
+
+	chain := NewFuncChain(
+		func(from t1, to *t2) error {
+			to.Name = from.Name
+			return nil
+		},
+		func(_ t2, _ *t3) error {
+			return errCustom
+		},
+	)
+
+	err := chain.Convert(t1{Name: "name"}, &t3{})
+
+	var convErr *ConversionError
+	require.True(t, errors.As(err, &convErr))
+	require.Equal(t, "", convErr.Path)
+	require.Equal(t, reflect.TypeFor[t2](), convErr.From)
+	require.Equal(t, reflect.TypeFor[t3](), convErr.To)
+	require.Equal(t, 1, convErr.Hop)
+	require.ErrorIs(t, err, errCustom)
+}
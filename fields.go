@@ -0,0 +1,218 @@
+package converter
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// tagName is the struct tag used to customize how fields are mapped, e.g.:
+//
+//	NewField string `convert:"was=OldField"`
+//
+// the following options are supported, separated by commas:
+//
+//	"-"        the field is ignored, it is never read from or written to
+//	name=X     the field is matched as if it were named X
+//	was=X      the field was previously named X; may be repeated
+//	optional   the field is expected to be left unset, so is not reported by WithUnmappedTargets
+//	encoding=X the encoding of bytes stored in a string, see ByteEncoding
+const tagName = "convert"
+
+// fieldInfo describes a struct field along with the options parsed from its struct tag
+type fieldInfo struct {
+	field    reflect.StructField
+	name     string
+	was      []string
+	ignore   bool
+	optional bool
+	encoding ByteEncoding
+}
+
+// matches indicates the fields should be mapped to each other based on their names, previous names are considered in
+// either direction so renames work for both forward and backward conversions
+func (f fieldInfo) matches(other fieldInfo, equal func(a, b string) bool) bool {
+	return equal(f.name, other.name) ||
+		slices.ContainsFunc(other.was, func(was string) bool { return equal(f.name, was) }) ||
+		slices.ContainsFunc(f.was, func(was string) bool { return equal(was, other.name) })
+}
+
+func newFieldInfo(field reflect.StructField) fieldInfo {
+	info := fieldInfo{
+		field: field,
+		name:  field.Name,
+	}
+
+	tag, ok := field.Tag.Lookup(tagName)
+	if !ok {
+		return info
+	}
+	if tag == "-" {
+		info.ignore = true
+		return info
+	}
+
+	for _, opt := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch key {
+		case "name":
+			info.name = value
+		case "was":
+			info.was = append(info.was, value)
+		case "optional":
+			info.optional = true
+		case "encoding":
+			info.encoding = ByteEncoding(value)
+		}
+	}
+
+	return info
+}
+
+// sourceFields returns the exported, directly declared fields of the struct type
+func sourceFields(typ reflect.Type) []fieldInfo {
+	var out []fieldInfo
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		out = append(out, newFieldInfo(field))
+	}
+	return out
+}
+
+// targetFields returns all the exported fields of the struct type which are able to be set, including fields
+// promoted from embedded structs, using the same visibility rules as reflect.Type.FieldByName
+func targetFields(typ reflect.Type) []fieldInfo {
+	var out []fieldInfo
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() || !isSettable(typ, field.Index) {
+			continue
+		}
+		// exclude fields hidden by shallower fields with the same name
+		if visible, ok := typ.FieldByName(field.Name); !ok || !slices.Equal(visible.Index, field.Index) {
+			continue
+		}
+		out = append(out, newFieldInfo(field))
+	}
+	return out
+}
+
+// mapKeyFields returns the fields of the struct type converted to and from map keys, which are the target fields that
+// are not ignored, with the fields of embedded structs in place of the embedded structs themselves
+func mapKeyFields(typ reflect.Type) []fieldInfo {
+	return slices.DeleteFunc(targetFields(typ), func(f fieldInfo) bool {
+		return f.ignore || f.field.Anonymous && isStruct(f.field.Type)
+	})
+}
+
+// isSettable returns false when the field is promoted through an embedded pointer, which would need to be allocated
+func isSettable(typ reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		typ = typ.Field(i).Type
+		if isPtr(typ) {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldMapping is a source field paired with the target field it is mapped to
+type fieldMapping struct {
+	from fieldInfo
+	to   fieldInfo
+}
+
+// encodings returns the byte encodings from the struct tags of the fields
+func (m fieldMapping) encodings() byteEncodings {
+	return byteEncodings{from: m.from.encoding, to: m.to.encoding}
+}
+
+// fieldPlan describes how the fields of one struct type are mapped to another
+type fieldPlan struct {
+	mappings []fieldMapping
+	// unmappedSources are the source fields, which are not ignored, with no target field
+	unmappedSources []fieldInfo
+	// unmappedTargets are the target fields, which are not ignored or optional, with no source field; embedded structs
+	// are not included, only their fields
+	unmappedTargets []fieldInfo
+}
+
+// mapFields pairs the fields of the source struct type with the target struct type; each target field is mapped from
+// the source field with the same name, if one exists, or otherwise the first source field matching a previous name.
+// When matching case-insensitively, the same is done ignoring case only if there is no match otherwise. The mappings
+// are in source field order.
+func mapFields(fromType, toType reflect.Type, matching FieldMatching) fieldPlan {
+	fromFields := sourceFields(fromType)
+	toFields := targetFields(toType)
+	var out []fieldMapping
+	for _, to := range toFields {
+		if to.ignore {
+			continue
+		}
+		from, ok := findSourceField(to, fromFields, isEqual)
+		if !ok && matching == FieldMatchCaseInsensitive {
+			from, ok = findSourceField(to, fromFields, strings.EqualFold)
+		}
+		if ok {
+			out = append(out, fieldMapping{from: from, to: to})
+		}
+	}
+	slices.SortStableFunc(out, func(a, b fieldMapping) int {
+		return a.from.field.Index[0] - b.from.field.Index[0]
+	})
+
+	var unmappedSources []fieldInfo
+	for _, from := range fromFields {
+		mapped := slices.ContainsFunc(out, func(m fieldMapping) bool {
+			return m.from.field.Index[0] == from.field.Index[0]
+		})
+		if !from.ignore && !mapped {
+			unmappedSources = append(unmappedSources, from)
+		}
+	}
+
+	var unmappedTargets []fieldInfo
+	for _, to := range toFields {
+		if to.ignore || to.optional || (to.field.Anonymous && isStruct(to.field.Type)) {
+			continue
+		}
+		// fields within a mapped embedded struct, or embedded structs with mapped fields, are set by the mapping
+		mapped := slices.ContainsFunc(out, func(m fieldMapping) bool {
+			return hasPrefix(m.to.field.Index, to.field.Index) || hasPrefix(to.field.Index, m.to.field.Index)
+		})
+		if !mapped {
+			unmappedTargets = append(unmappedTargets, to)
+		}
+	}
+
+	return fieldPlan{
+		mappings:        out,
+		unmappedSources: unmappedSources,
+		unmappedTargets: unmappedTargets,
+	}
+}
+
+// hasPrefix returns true if the field index starts with the prefix
+func hasPrefix(index, prefix []int) bool {
+	return len(index) >= len(prefix) && slices.Equal(index[:len(prefix)], prefix)
+}
+
+func findSourceField(to fieldInfo, fromFields []fieldInfo, equal func(a, b string) bool) (fieldInfo, bool) {
+	for _, from := range fromFields {
+		if !from.ignore && equal(from.name, to.name) {
+			return from, true
+		}
+	}
+	for _, from := range fromFields {
+		if !from.ignore && from.matches(to, equal) {
+			return from, true
+		}
+	}
+	return fieldInfo{}, false
+}
+
+func isEqual(a, b string) bool {
+	return a == b
+}
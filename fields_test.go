@@ -0,0 +1,95 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StructTags(t *testing.T) {
+	type v1 struct {
+		Name     string
+		OldField string
+		Internal string
+	}
+
+	type v2 struct {
+		Name     string
+		NewField string `convert:"was=OldField"`
+		Internal string `convert:"-"`
+	}
+
+	type v3 struct {
+		Title    string `convert:"name=Name"`
+		Renamed  string `convert:"was=Other,was=NewField"`
+		Internal string
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+
+	from := v1{
+		Name:     "the name",
+		OldField: "the value",
+		Internal: "internal",
+	}
+
+	forward := v2{}
+	require.NoError(t, chain.Convert(from, &forward))
+	require.Equal(t, v2{
+		Name:     "the name",
+		NewField: "the value",
+	}, forward)
+
+	backward := v1{}
+	require.NoError(t, chain.Convert(forward, &backward))
+	require.Equal(t, v1{
+		Name:     "the name",
+		OldField: "the value",
+	}, backward)
+
+	renamed := v3{}
+	require.NoError(t, chain.Convert(forward, &renamed))
+	require.Equal(t, v3{
+		Title:   "the name",
+		Renamed: "the value",
+	}, renamed)
+}
+
+func Test_StructTagsPreferExactName(t *testing.T) {
+	type from struct {
+		Value    string
+		OldValue string
+	}
+
+	type to struct {
+		Value    string `convert:"was=OldValue"`
+		OldValue string `convert:"-"`
+	}
+
+	got := to{}
+	err := NewFuncChain().AllowImplicit().Convert(from{Value: "current", OldValue: "previous"}, &got)
+	require.NoError(t, err)
+	require.Equal(t, "current", got.Value)
+	require.Empty(t, got.OldValue)
+}
+
+func Test_PromotedFields(t *testing.T) {
+	type Base struct {
+		Name string
+	}
+
+	type from struct {
+		Name    string
+		Version string
+	}
+
+	type to struct {
+		Base
+		Version string
+	}
+
+	got := to{}
+	err := NewFuncChain().AllowImplicit().Convert(from{Name: "name", Version: "1.0"}, &got)
+	require.NoError(t, err)
+	require.Equal(t, to{Base: Base{Name: "name"}, Version: "1.0"}, got)
+}
@@ -0,0 +1,905 @@
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"maps"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GenerateOptions configures the code produced by Generate
+type GenerateOptions struct {
+	// Package is the name of the package the code is generated in
+	Package string
+	// PackagePath is the import path of the package the code is generated in, types and functions in this package are
+	// referenced without a package qualifier
+	PackagePath string
+	// Pairs are the types to generate conversion functions for
+	Pairs []TypePair
+	// FieldMatching is the strategy used to match struct fields, the same as WithFieldMatching for Convert
+	FieldMatching FieldMatching
+}
+
+// TypePair is a source and target type to generate a conversion function for
+type TypePair struct {
+	From reflect.Type
+	To   reflect.Type
+	// Name is the name of the generated function, by default this is ConvertXToY
+	Name string
+}
+
+// Pair returns a TypePair for the provided types
+func Pair[From, To any]() TypePair {
+	return TypePair{
+		From: reflect.TypeFor[From](),
+		To:   reflect.TypeFor[To](),
+	}
+}
+
+// Generate returns Go source code with a function for each of the type pairs, which performs the same conversion as
+// the chain would using Convert with no options other than the field matching, but without reflection: fields are
+// mapped, the conversion path is followed and the registered converter functions and conversion methods are called
+// directly. The generated functions have the form:
+//
+//	func ConvertXToY(chain converter.FuncChain, from X, to *Y) error
+//
+// To be called from generated code, converter functions must be declared at the top level of a package, not as
+// function literals. Conversions which cannot be expressed in generated code result in an error. Unlike Convert, the
+// generated code does not track the pointers visited, so shared pointers are copied and cyclic values are unsupported,
+// and times are not able to be converted to or from numbers. The generated functions also return the first error
+// encountered as it is, rather than joining a *ConversionError with the field path for each failed field.
+//
+// The generated code has a //go:build !convertgen constraint, so convertgen loads the chain without it. Other code in
+// the package which calls the generated functions is not excluded, so it fails to build while loading the chain; such
+// calls need to be in test files, or in files with the same constraint.
+func Generate(chain FuncChain, opts GenerateOptions) ([]byte, error) {
+	c, ok := chain.(*funcChain)
+	if !ok {
+		return nil, fmt.Errorf("unsupported chain type: %T", chain)
+	}
+
+	g := &generator{
+		chain:     c,
+		pkgPath:   opts.PackagePath,
+		matching:  opts.FieldMatching,
+		imports:   map[string]string{},
+		functions: map[typePair]string{},
+	}
+
+	for _, pair := range opts.Pairs {
+		if err := g.entryFunc(pair); err != nil {
+			return nil, err
+		}
+	}
+
+	for len(g.pending) > 0 {
+		pair := g.pending[0]
+		g.pending = g.pending[1:]
+		if err := g.pairFunc(pair); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.source(opts.Package)
+}
+
+// generator accumulates generated functions along with the imports they need
+type generator struct {
+	chain     *funcChain
+	pkgPath   string
+	matching  FieldMatching
+	imports   map[string]string // import path -> name
+	functions map[typePair]string
+	pending   []typePair
+	body      bytes.Buffer
+	// encodings are the byte encodings set by the struct tags of the field being converted
+	encodings byteEncodings
+}
+
+// funcWriter writes the body of a single function
+type funcWriter struct {
+	g    *generator
+	buf  bytes.Buffer
+	vars int
+}
+
+func (w *funcWriter) line(format string, args ...any) {
+	fmt.Fprintf(&w.buf, format, args...)
+	w.buf.WriteString("\n")
+}
+
+// newVar returns a unique variable name within the function
+func (w *funcWriter) newVar(prefix string) string {
+	w.vars++
+	return prefix + strconv.Itoa(w.vars)
+}
+
+func (g *generator) source(pkg string) ([]byte, error) {
+	var out bytes.Buffer
+	out.WriteString("// Code generated by convertgen. DO NOT EDIT.\n\n")
+	out.WriteString("//go:build !convertgen\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+
+	if len(g.imports) > 0 {
+		// standard library imports are listed first, in a separate group
+		paths := slices.SortedFunc(maps.Keys(g.imports), func(a, b string) int {
+			if isStdLib(a) != isStdLib(b) {
+				if isStdLib(a) {
+					return -1
+				}
+				return 1
+			}
+			return strings.Compare(a, b)
+		})
+		out.WriteString("import (\n")
+		for i, path := range paths {
+			if i > 0 && isStdLib(path) != isStdLib(paths[i-1]) {
+				out.WriteString("\n")
+			}
+			// the name of a package may not match its path, so other packages are always imported with a name
+			if isStdLib(path) {
+				fmt.Fprintf(&out, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(&out, "\t%s %q\n", g.imports[path], path)
+			}
+		}
+		out.WriteString(")\n\n")
+	}
+
+	out.Write(g.body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to format generated code: %w\n%s", err, out.String())
+	}
+	return src, nil
+}
+
+// entryFunc generates the exported function following the conversion path between the types
+func (g *generator) entryFunc(pair TypePair) error {
+	fromType := baseType(pair.From)
+	toType := baseType(pair.To)
+
+	g.chain.inspectTypes(fromType, toType)
+	route := g.chain.route(fromType, toType)
+	if len(route) == 0 {
+		return fmt.Errorf("no conversion path found from %s to %s", typeName(fromType), typeName(toType))
+	}
+
+	name := pair.Name
+	if name == "" {
+		name = "Convert" + g.typeIdent(fromType) + "To" + g.typeIdent(toType)
+	}
+
+	w := &funcWriter{g: g}
+	steps := []string{g.typeIdent(fromType)}
+	for i, step := range route {
+		if i < len(route)-1 {
+			steps = append(steps, g.typeIdent(step.targetType))
+		}
+	}
+	steps = append(steps, g.typeIdent(toType))
+	last, err := g.convertRoute(w, "from", fromType, toType, route)
+	if err != nil {
+		return err
+	}
+	w.line("*to = %s", last)
+	w.line("return nil")
+
+	return g.writeFunc(name, fmt.Sprintf("converts %s to %s: %s", nameOf(fromType), nameOf(toType), strings.Join(steps, " -> ")), fromType, toType, w)
+}
+
+// convertRoute writes code converting the src expression along the route, returning the variable holding the result
+func (g *generator) convertRoute(w *funcWriter, src string, srcType, dstType reflect.Type, route []reflectConvertStep) (string, error) {
+	last := src
+	lastType := srcType
+	for i, step := range route {
+		stepType := step.targetType
+		if i == len(route)-1 {
+			stepType = dstType
+		}
+		// an implicit conversion is a step to the same type, rather than to the target type
+		if (!isStruct(lastType) || !isStruct(stepType)) && step.targetType != stepType {
+			return "", fmt.Errorf("unable to generate conversion from %s to %s: only structs are supported by implicit conversions", nameOf(lastType), nameOf(stepType))
+		}
+		typ, err := g.typeExpr(stepType)
+		if err != nil {
+			return "", err
+		}
+		next := w.newVar("v")
+		w.line("var %s %s", next, typ)
+		w.line("if err := %s(chain, %s, &%s); err != nil {", g.pairFuncName(lastType, stepType), last, next)
+		w.line("return err")
+		w.line("}")
+		last = next
+		lastType = stepType
+	}
+	return last, nil
+}
+
+// pairFuncName returns the name of the function converting between the types, which is generated later
+func (g *generator) pairFuncName(fromType, toType reflect.Type) string {
+	pair := typePair{fromType, toType}
+	if name, ok := g.functions[pair]; ok {
+		return name
+	}
+	name := "convert" + g.typeIdent(fromType) + "To" + g.typeIdent(toType)
+	g.functions[pair] = name
+	g.pending = append(g.pending, pair)
+	return name
+}
+
+// pairFunc generates a function mapping each field of structs, then calling a registered conversion, as
+// getStructValue does; other types are only converted by the registered conversion, as getRouteValue does
+func (g *generator) pairFunc(pair typePair) error {
+	w := &funcWriter{g: g}
+
+	for _, m := range g.mappings(pair) {
+		src := "from." + m.from.field.Name
+		dst := "to." + m.to.field.Name
+		g.encodings = m.encodings()
+		err := g.convertValue(w, src, m.from.field.Type, m.to.field.Type, func(v string) {
+			w.line("%s = %s", dst, v)
+		})
+		g.encodings = byteEncodings{}
+		if err != nil {
+			return fmt.Errorf("unable to generate conversion of field %s from %s to %s: %w", m.from.field.Name, nameOf(pair.from), nameOf(pair.to), err)
+		}
+	}
+
+	g.chain.lock.RLock()
+	step, exists := g.chain.funcs[pair.from][pair.to]
+	g.chain.lock.RUnlock()
+	if exists {
+		if err := g.callConversion(w, step, pair); err != nil {
+			return err
+		}
+	}
+	w.line("return nil")
+
+	return g.writeFunc(g.functions[pair], fmt.Sprintf("converts %s to %s", nameOf(pair.from), nameOf(pair.to)), pair.from, pair.to, w)
+}
+
+// mappings returns the fields to map between the types, which are only mapped between structs
+func (g *generator) mappings(pair typePair) []fieldMapping {
+	if !isStruct(pair.from) || !isStruct(pair.to) {
+		return nil
+	}
+	return structPlan(pair.from, pair.to, g.matching).mappings
+}
+
+func (g *generator) writeFunc(name, doc string, fromType, toType reflect.Type, w *funcWriter) error {
+	from, err := g.typeExpr(fromType)
+	if err != nil {
+		return err
+	}
+	to, err := g.typeExpr(toType)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.body, "// %s %s\n", name, doc)
+	fmt.Fprintf(&g.body, "func %s(chain %s, from %s, to *%s) error {\n", name, g.qualified(converterPkgPath, "FuncChain"), from, to)
+	g.body.Write(w.buf.Bytes())
+	g.body.WriteString("}\n\n")
+	return nil
+}
+
+// callConversion writes a call to the registered converter function or conversion method
+func (g *generator) callConversion(w *funcWriter, step reflectConvertStep, pair typePair) error {
+	origin := step.origin
+	switch {
+	case origin == nil:
+		return fmt.Errorf("unable to generate conversion from %s to %s: conversion functions added by AddConvertFunc are not supported", nameOf(pair.from), nameOf(pair.to))
+	case origin.noop && isStruct(pair.from) && isStruct(pair.to):
+		return nil
+	case origin.noop:
+		// other types connected only to route through them are converted implicitly, as getRouteValue does
+		return g.convertValue(w, "from", pair.from, pair.to, func(v string) {
+			w.line("*to = %s", v)
+		})
+	}
+
+	var call string
+	var fnType reflect.Type
+	if origin.method != nil {
+		fnType = origin.method.Type
+		receiver, arg := "from", "to"
+		if origin.isConvertFrom {
+			receiver, arg = "to", "from"
+		}
+		args := []string{g.argExpr(arg, fnType.In(fnType.NumIn()-1))}
+		if origin.hasChainParam {
+			args = append([]string{"chain"}, args...)
+		}
+		call = fmt.Sprintf("%s.%s(%s)", receiver, origin.method.Name, strings.Join(args, ", "))
+	} else {
+		fnType = origin.fn.Type()
+		name, err := g.funcExpr(origin.fn)
+		if err != nil {
+			return fmt.Errorf("unable to generate conversion from %s to %s: %w", nameOf(pair.from), nameOf(pair.to), err)
+		}
+		args := []string{g.argExpr("from", fnType.In(fnType.NumIn()-2)), g.argExpr("to", fnType.In(fnType.NumIn()-1))}
+		if origin.hasChainParam {
+			args = append([]string{"chain"}, args...)
+		}
+		call = fmt.Sprintf("%s(%s)", name, strings.Join(args, ", "))
+	}
+
+	if fnType.NumOut() == 0 {
+		w.line("%s", call)
+		return nil
+	}
+	w.line("if err := %s; err != nil {", call)
+	w.line("return err")
+	w.line("}")
+	return nil
+}
+
+// argExpr adapts the function parameters, from is a value and to is a pointer, to the argument type
+func (g *generator) argExpr(name string, argType reflect.Type) string {
+	switch {
+	case name == "from" && isPtr(argType):
+		return "&from"
+	case name == "to" && !isPtr(argType):
+		return "*to"
+	}
+	return name
+}
+
+// funcExpr returns the expression referencing a top-level function
+func (g *generator) funcExpr(fn reflect.Value) (string, error) {
+	f := runtime.FuncForPC(fn.Pointer())
+	if f == nil {
+		return "", fmt.Errorf("unable to find function: %v", fn.Type())
+	}
+	fullName := f.Name()
+
+	// names are in the form: github.com/org/pkg.Func, the last path element may contain escaped dots
+	slash := strings.LastIndex(fullName, "/")
+	dot := strings.Index(fullName[slash+1:], ".")
+	if dot < 0 {
+		return "", fmt.Errorf("unable to determine package of function: %s", fullName)
+	}
+	pkgPath := strings.ReplaceAll(fullName[:slash+1+dot], "%2e", ".")
+	name := fullName[slash+1+dot+1:]
+
+	if !token.IsIdentifier(name) {
+		return "", fmt.Errorf("converter functions must be declared at the top level of a package to generate code; got: %s", fullName)
+	}
+	if pkgPath != g.pkgPath && !token.IsExported(name) {
+		return "", fmt.Errorf("converter function must be exported to be called from another package: %s", fullName)
+	}
+	return g.qualified(pkgPath, name), nil
+}
+
+// convertValue writes code converting the src expression, calling set with an expression of dstType when there is a
+// value to set; this mirrors conversion.getValue
+func (g *generator) convertValue(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	if isPtr(srcType) {
+		srcType = srcType.Elem()
+		if g.chain.preserveZeroValues || g.hasCoercer(srcType, baseType(dstType)) {
+			w.line("if %s != nil {", src)
+		} else {
+			notZero, err := g.notZero("*"+src, srcType)
+			if err != nil {
+				return err
+			}
+			w.line("if %s != nil && %s {", src, notZero)
+		}
+		if err := g.convertValue(w, "*"+src, srcType, dstType, set); err != nil {
+			return err
+		}
+		w.line("}")
+		return nil
+	}
+
+	baseDstType := dstType
+	if isPtr(dstType) {
+		baseDstType = dstType.Elem()
+		setValue := set
+		set = func(v string) {
+			ptr := w.newVar("p")
+			w.line("%s := %s", ptr, v)
+			setValue("&" + ptr)
+		}
+	}
+
+	switch {
+	case g.hasCoercer(srcType, baseDstType):
+		// converted by convertValueTypes
+	case isInterface(baseDstType):
+		return fmt.Errorf("interface types are not supported: %s", nameOf(baseDstType))
+	case srcType != baseDstType && !(isStruct(srcType) && isStruct(baseDstType)) && g.hasRoute(srcType, baseDstType):
+		v, err := g.convertRoute(w, src, srcType, baseDstType, g.chain.registeredRoute(srcType, baseDstType))
+		if err != nil {
+			return err
+		}
+		set(v)
+		return nil
+	case srcType == baseDstType && isOpaque(srcType):
+		set(src)
+		return nil
+	case isStruct(srcType) && isStruct(baseDstType):
+		return g.convertStruct(w, src, srcType, baseDstType, set)
+	case isSlice(srcType) && isSlice(baseDstType):
+		return g.convertSlice(w, src, srcType, baseDstType, set)
+	case isMap(srcType) && isMap(baseDstType):
+		return g.convertMap(w, src, srcType, baseDstType, set)
+	}
+	return g.convertValueTypes(w, src, srcType, baseDstType, set)
+}
+
+func (g *generator) convertStruct(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	typ, err := g.typeExpr(dstType)
+	if err != nil {
+		return err
+	}
+	v := w.newVar("s")
+	w.line("var %s %s", v, typ)
+	w.line("if err := %s(chain, %s, &%s); err != nil {", g.pairFuncName(srcType, dstType), src, v)
+	w.line("return err")
+	w.line("}")
+	set(v)
+	return nil
+}
+
+func (g *generator) convertSlice(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	typ, err := g.typeExpr(dstType)
+	if err != nil {
+		return err
+	}
+	out := w.newVar("l")
+	i := w.newVar("i")
+	elem := w.newVar("e")
+	w.line("if %s != nil {", src)
+	w.line("%s := make(%s, len(%s))", out, typ, src)
+	w.line("for %s, %s := range %s {", i, elem, src)
+	err = g.convertValue(w, elem, srcType.Elem(), dstType.Elem(), func(v string) {
+		w.line("%s[%s] = %s", out, i, v)
+	})
+	if err != nil {
+		return err
+	}
+	w.line("}")
+	set(out)
+	w.line("}")
+	return nil
+}
+
+func (g *generator) convertMap(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	typ, err := g.typeExpr(dstType)
+	if err != nil {
+		return err
+	}
+	out := w.newVar("m")
+	key := w.newVar("k")
+	elem := w.newVar("e")
+	w.line("if %s != nil {", src)
+	w.line("%s := make(%s, len(%s))", out, typ, src)
+	w.line("for %s, %s := range %s {", key, elem, src)
+	var elemErr error
+	err = g.convertValue(w, key, srcType.Key(), dstType.Key(), func(k string) {
+		elemErr = g.convertValue(w, elem, srcType.Elem(), dstType.Elem(), func(v string) {
+			w.line("%s[%s] = %s", out, k, v)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if elemErr != nil {
+		return elemErr
+	}
+	w.line("}")
+	set(out)
+	w.line("}")
+	return nil
+}
+
+// convertValueTypes writes code converting between scalar types, this mirrors conversion.convertValueTypes
+func (g *generator) convertValueTypes(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	switch {
+	case srcType == dstType:
+		set(src)
+		return nil
+	case g.hasCoercer(srcType, dstType):
+		return g.callCoercer(w, src, srcType, dstType, set)
+	case srcType.Kind() == dstType.Kind() && srcType.ConvertibleTo(dstType):
+		typ, err := g.typeExpr(dstType)
+		if err != nil {
+			return err
+		}
+		set(fmt.Sprintf("%s(%s)", typ, src))
+		return nil
+	case isPrimitive(dstType) || isTimeText(srcType, dstType) || isMarshaledText(srcType, dstType) ||
+		isUnmarshaledText(srcType, dstType) || isByteString(srcType, dstType):
+		if g.chain.preserveZeroValues && !isString(srcType) && !isTimeText(srcType, dstType) {
+			// zero values other than empty strings are converted like any other value when preserved
+			return g.convertNonZeroValue(w, src, srcType, dstType, set)
+		}
+		// zero values are not set, unless preserving them
+		notZero, err := g.notZero(src, srcType)
+		if err != nil {
+			return err
+		}
+		w.line("if %s {", notZero)
+		if err := g.convertNonZeroValue(w, src, srcType, dstType, set); err != nil {
+			return err
+		}
+		if g.chain.preserveZeroValues {
+			typ, err := g.typeExpr(dstType)
+			if err != nil {
+				return err
+			}
+			w.line("} else {")
+			set(fmt.Sprintf("*new(%s)", typ))
+		}
+		w.line("}")
+		return nil
+	}
+	return g.convertNonZeroValue(w, src, srcType, dstType, set)
+}
+
+func (g *generator) hasRoute(srcType, dstType reflect.Type) bool {
+	g.chain.inspectTypes(srcType, dstType)
+	return isRoute(g.chain.registeredRoute(srcType, dstType), srcType, dstType)
+}
+
+func (g *generator) hasCoercer(srcType, dstType reflect.Type) bool {
+	_, ok := g.chain.coercers[typePair{srcType, dstType}]
+	return ok
+}
+
+// callCoercer writes a call to the coercer registered with AddCoercer
+func (g *generator) callCoercer(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	name, err := g.funcExpr(g.chain.coercers[typePair{srcType, dstType}])
+	if err != nil {
+		return fmt.Errorf("unable to generate conversion from %s to %s: %w", nameOf(srcType), nameOf(dstType), err)
+	}
+	v := w.newVar("c")
+	w.line("%s, err := %s(%s)", v, name, src)
+	w.line("if err != nil {")
+	w.line("return err")
+	w.line("}")
+	set(v)
+	return nil
+}
+
+func (g *generator) convertNonZeroValue(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	switch {
+	case isTimeText(srcType, dstType):
+		return g.convertTimeText(w, src, srcType, dstType, set)
+	case isMarshaledText(srcType, dstType) || isUnmarshaledText(srcType, dstType):
+		return g.convertText(w, src, srcType, dstType, set)
+	case isByteString(srcType, dstType):
+		return g.convertByteString(w, src, srcType, dstType, set)
+	case isPrimitive(srcType) && isPrimitive(dstType):
+		return g.convertPrimitive(w, src, srcType, dstType, set)
+	case isSlice(srcType) && !isSlice(dstType):
+		// this may be lossy
+		w.line("if len(%s) > 0 {", src)
+		if err := g.convertValueTypes(w, src+"[0]", srcType.Elem(), dstType, set); err != nil {
+			return err
+		}
+		w.line("}")
+		return nil
+	case isSlice(dstType) && !isSlice(srcType):
+		typ, err := g.typeExpr(dstType)
+		if err != nil {
+			return err
+		}
+		return g.convertValueTypes(w, src, srcType, dstType.Elem(), func(v string) {
+			set(fmt.Sprintf("%s{%s}", typ, v))
+		})
+	}
+	return fmt.Errorf("unable to convert from %s to %s", nameOf(srcType), nameOf(dstType))
+}
+
+// convertTimeText writes code converting between a time or duration and a string, the same as conversion.convertTime
+// does using the default time layout; times are not able to be converted to or from numbers
+func (g *generator) convertTimeText(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	typ, err := g.typeExpr(dstType)
+	if err != nil {
+		return err
+	}
+	timePkg := g.importPkg("time")
+	switch {
+	case srcType == timeType:
+		set(convertExpr(typ, fmt.Sprintf("%s.Format(%s.RFC3339Nano)", receiverExpr(src), timePkg), dstType == stringType))
+		return nil
+	case srcType == durationType:
+		set(convertExpr(typ, receiverExpr(src)+".String()", dstType == stringType))
+		return nil
+	}
+
+	str := convertExpr("string", src, srcType == stringType)
+	v := w.newVar("t")
+	if dstType == timeType {
+		w.line("%s, err := %s.Parse(%s.RFC3339Nano, %s)", v, timePkg, timePkg, str)
+	} else {
+		w.line("%s, err := %s.ParseDuration(%s)", v, timePkg, str)
+	}
+	w.line("if err != nil {")
+	w.line("return err")
+	w.line("}")
+	set(v)
+	return nil
+}
+
+// convertText writes code converting to or from a string using encoding.TextMarshaler or encoding.TextUnmarshaler, the
+// same as conversion.convertText
+func (g *generator) convertText(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	typ, err := g.typeExpr(dstType)
+	if err != nil {
+		return err
+	}
+	if isMarshaledText(srcType, dstType) {
+		text := w.newVar("t")
+		w.line("%s, err := %s.MarshalText()", text, receiverExpr(src))
+		w.line("if err != nil {")
+		w.line("return err")
+		w.line("}")
+		set(fmt.Sprintf("%s(%s)", typ, text))
+		return nil
+	}
+	v := w.newVar("t")
+	w.line("var %s %s", v, typ)
+	w.line("if err := %s.UnmarshalText([]byte(%s)); err != nil {", v, src)
+	w.line("return err")
+	w.line("}")
+	set(v)
+	return nil
+}
+
+// convertByteString writes code converting between a string and a slice of bytes or runes, the same as
+// conversion.convertByteString does
+func (g *generator) convertByteString(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	typ, err := g.typeExpr(dstType)
+	if err != nil {
+		return err
+	}
+	sliceType := srcType
+	if isSlice(dstType) {
+		sliceType = dstType
+	}
+	encoding := g.encodings.of(isString(srcType), ByteEncodingRaw)
+	if isRune(sliceType.Elem()) || encoding == ByteEncodingRaw {
+		set(fmt.Sprintf("%s(%s)", typ, src))
+		return nil
+	}
+	if !sliceType.ConvertibleTo(bytesType) {
+		return fmt.Errorf("unable to convert from %s to %s", nameOf(srcType), nameOf(dstType))
+	}
+
+	var pkg string
+	switch encoding {
+	case ByteEncodingHex:
+		pkg = g.importPkg("encoding/hex")
+	case ByteEncodingBase64:
+		pkg = g.importPkg("encoding/base64") + ".StdEncoding"
+	default:
+		return fmt.Errorf("unknown byte encoding: %q", encoding)
+	}
+
+	if isString(dstType) {
+		set(convertExpr(typ, fmt.Sprintf("%s.EncodeToString(%s)", pkg, convertExpr("[]byte", src, srcType == bytesType)), dstType == stringType))
+		return nil
+	}
+	v := w.newVar("b")
+	w.line("%s, err := %s.DecodeString(%s)", v, pkg, convertExpr("string", src, srcType == stringType))
+	w.line("if err != nil {")
+	w.line("return err")
+	w.line("}")
+	set(convertExpr(typ, v, dstType == bytesType))
+	return nil
+}
+
+// convertPrimitive writes code converting between primitive types, values are formatted the same as the primitive
+// coercers and are otherwise converted by Coerce, which uses the same rules
+func (g *generator) convertPrimitive(w *funcWriter, src string, srcType, dstType reflect.Type, set func(v string)) error {
+	typ, err := g.typeExpr(dstType)
+	if err != nil {
+		return err
+	}
+
+	if !isString(dstType) {
+		v := w.newVar("c")
+		w.line("%s, err := %s[%s](%s)", v, g.qualified(converterPkgPath, "Coerce"), typ, src)
+		w.line("if err != nil {")
+		w.line("return err")
+		w.line("}")
+		set(v)
+		return nil
+	}
+
+	strconvPkg := g.importPkg("strconv")
+
+	var str string
+	switch {
+	case srcType == stringType:
+		str = src
+	case isString(srcType):
+		str = fmt.Sprintf("string(%s)", src)
+	case isBool(srcType):
+		str = fmt.Sprintf("%s.FormatBool(bool(%s))", strconvPkg, src)
+	case isInt(srcType):
+		str = fmt.Sprintf("%s.FormatInt(int64(%s), 10)", strconvPkg, src)
+	case isUint(srcType):
+		str = fmt.Sprintf("%s.FormatUint(uint64(%s), 10)", strconvPkg, src)
+	case isFloat(srcType):
+		str = fmt.Sprintf("%s.FormatFloat(float64(%s), 'g', -1, %d)", strconvPkg, src, srcType.Bits())
+	}
+	set(convertExpr(typ, str, dstType == stringType))
+	return nil
+}
+
+// receiverExpr returns the expression to call a method on, a dereferenced pointer needs parentheses
+func receiverExpr(value string) string {
+	if strings.HasPrefix(value, "*") {
+		return "(" + value + ")"
+	}
+	return value
+}
+
+// convertExpr returns the expression converting the value to the type, unless it is already the same type
+func convertExpr(typ, value string, sameType bool) string {
+	if sameType {
+		return value
+	}
+	return fmt.Sprintf("%s(%s)", typ, value)
+}
+
+// notZero returns an expression which is true when the expression is not the zero value of its type
+func (g *generator) notZero(expr string, t reflect.Type) (string, error) {
+	switch {
+	case isString(t):
+		return fmt.Sprintf(`%s != ""`, expr), nil
+	case isBool(t):
+		return expr, nil
+	case isInt(t), isUint(t), isFloat(t):
+		return fmt.Sprintf("%s != 0", expr), nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		return fmt.Sprintf("%s != nil", expr), nil
+	case reflect.Struct, reflect.Array:
+		if t.Comparable() {
+			typ, err := g.typeExpr(t)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s != (%s{})", expr, typ), nil
+		}
+	default:
+	}
+	return fmt.Sprintf("!%s.ValueOf(%s).IsZero()", g.importPkg("reflect"), expr), nil
+}
+
+// typeExpr returns the expression referencing the type from the generated code
+func (g *generator) typeExpr(t reflect.Type) (string, error) {
+	if t.Name() != "" {
+		if strings.Contains(t.Name(), "[") {
+			return "", fmt.Errorf("generic types are not supported: %s", t)
+		}
+		if t.PkgPath() == "" {
+			return t.Name(), nil
+		}
+		if t.PkgPath() != g.pkgPath && !token.IsExported(t.Name()) {
+			return "", fmt.Errorf("type must be exported to be referenced from another package: %s", typeName(t))
+		}
+		return g.qualified(t.PkgPath(), t.Name()), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, err := g.typeExpr(t.Elem())
+		return "*" + elem, err
+	case reflect.Slice:
+		elem, err := g.typeExpr(t.Elem())
+		return "[]" + elem, err
+	case reflect.Array:
+		elem, err := g.typeExpr(t.Elem())
+		return fmt.Sprintf("[%d]%s", t.Len(), elem), err
+	case reflect.Map:
+		key, err := g.typeExpr(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := g.typeExpr(t.Elem())
+		return fmt.Sprintf("map[%s]%s", key, elem), err
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any", nil
+		}
+	default:
+	}
+	return "", fmt.Errorf("unsupported type: %s", t)
+}
+
+// typeIdent returns a name for the type which may be used as part of an identifier, types from other packages are
+// prefixed with the package name
+func (g *generator) typeIdent(t reflect.Type) string {
+	if t.PkgPath() == "" || t.PkgPath() == g.pkgPath {
+		return upperFirst(t.Name())
+	}
+	pkg, _, _ := strings.Cut(t.String(), ".")
+	return upperFirst(pkg) + upperFirst(t.Name())
+}
+
+// qualified returns the expression referencing the named declaration in the package, adding an import if needed
+func (g *generator) qualified(path, name string) string {
+	if path == g.pkgPath {
+		return name
+	}
+	return g.importPkg(path) + "." + name
+}
+
+// importPkg adds an import for the package path, returning the name to reference it by
+func (g *generator) importPkg(path string) string {
+	if name, ok := g.imports[path]; ok {
+		return name
+	}
+	base := importName(path)
+	name := base
+	for i := 2; slices.Contains(slices.Collect(maps.Values(g.imports)), name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.imports[path] = name
+	return name
+}
+
+// importName returns the name a package is expected to have, based on its import path
+func importName(path string) string {
+	if path == converterPkgPath {
+		return "converter"
+	}
+	name := path[strings.LastIndex(path, "/")+1:]
+	// major version suffixes, e.g. github.com/org/pkg/v2
+	if isMajorVersion(name) {
+		if parent := strings.TrimSuffix(path, "/"+name); parent != path {
+			name = parent[strings.LastIndex(parent, "/")+1:]
+		}
+	}
+	// gopkg.in style versions, e.g. gopkg.in/yaml.v3
+	if base, version, ok := strings.Cut(name, "."); ok && isMajorVersion(version) {
+		name = base
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	return name
+}
+
+func isMajorVersion(s string) bool {
+	return len(s) > 1 && s[0] == 'v' && strings.Trim(s[1:], "0123456789") == ""
+}
+
+// isStdLib returns true for standard library packages, which do not have a domain as the first path element
+func isStdLib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var (
+	// converterPkgPath is the import path of this package
+	converterPkgPath = reflect.TypeFor[funcChain]().PkgPath()
+
+	stringType = reflect.TypeFor[string]()
+	bytesType  = reflect.TypeFor[[]byte]()
+)
@@ -0,0 +1,122 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GenerateErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		chain       FuncChain
+		pair        TypePair
+		errorSubstr string
+	}{
+		{
+			name:        "no path",
+			chain:       NewFuncChain(),
+			pair:        Pair[t1, t3](),
+			errorSubstr: "no conversion path",
+		},
+		{
+			name: "function literal",
+			chain: NewFuncChain(func(_ t1, _ *t2) error {
+				return nil
+			}),
+			pair:        Pair[t1, t2](),
+			errorSubstr: "top level",
+		},
+		{
+			name: "convert func",
+			chain: func() FuncChain {
+				c := NewFuncChain().(*funcChain)
+				c.AddConvertFunc(reflect.TypeFor[t1](), reflect.TypeFor[t2](), func(_, _ reflect.Value) error {
+					return nil
+				})
+				return c
+			}(),
+			pair:        Pair[t1, t2](),
+			errorSubstr: "AddConvertFunc",
+		},
+		{
+			name:        "non-struct",
+			chain:       NewFuncChain().AllowImplicit(),
+			pair:        Pair[string, int](),
+			errorSubstr: "only structs",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Generate(test.chain, GenerateOptions{
+				Package:     "converter",
+				PackagePath: converterPkgPath,
+				Pairs:       []TypePair{test.pair},
+			})
+			require.ErrorContains(t, err, test.errorSubstr)
+		})
+	}
+}
+
+func Test_GenerateFunctionReferences(t *testing.T) {
+	chain := NewFuncChain(t1ToT2, t2ToT3)
+
+	src, err := Generate(chain, GenerateOptions{
+		Package:     "other",
+		PackagePath: "example.com/other",
+		Pairs:       []TypePair{Pair[t1, t2]()},
+	})
+	require.Error(t, err, "unexported functions cannot be referenced from another package")
+	require.Nil(t, src)
+
+	src, err = Generate(chain, GenerateOptions{
+		Package:     "converter",
+		PackagePath: converterPkgPath,
+		Pairs:       []TypePair{Pair[t1, t3]()},
+	})
+	require.NoError(t, err)
+	require.Contains(t, string(src), "func ConvertT1ToT3(chain FuncChain, from t1, to *t3) error {")
+	require.Contains(t, string(src), "if err := t1ToT2(from, to); err != nil {")
+	require.Contains(t, string(src), "if err := t2ToT3(from, to); err != nil {")
+}
+
+func Test_GeneratePreserveZeroValues(t *testing.T) {
+	type from struct {
+		Value   *string
+		Count   string
+		Enabled bool
+	}
+	type to struct {
+		Value   *string
+		Count   int
+		Enabled string
+	}
+
+	src, err := Generate(NewFuncChain().AllowImplicit(), GenerateOptions{
+		Package:     "converter",
+		PackagePath: converterPkgPath,
+		Pairs:       []TypePair{Pair[from, to]()},
+	})
+	require.NoError(t, err)
+	require.Contains(t, string(src), `if from.Value != nil && *from.Value != "" {`)
+	require.NotContains(t, string(src), "} else {")
+
+	src, err = Generate(NewFuncChain().AllowImplicit().PreserveZeroValues(), GenerateOptions{
+		Package:     "converter",
+		PackagePath: converterPkgPath,
+		Pairs:       []TypePair{Pair[from, to]()},
+	})
+	require.NoError(t, err)
+	require.Contains(t, string(src), "if from.Value != nil {")
+	require.Contains(t, string(src), "to.Count = *new(int)")
+	require.NotContains(t, string(src), "if from.Enabled {")
+}
+
+func Test_importName(t *testing.T) {
+	require.Equal(t, "converter", importName(converterPkgPath))
+	require.Equal(t, "yaml", importName("gopkg.in/yaml.v3"))
+	require.Equal(t, "pkg", importName("github.com/org/pkg/v2"))
+	require.Equal(t, "go_cmp", importName("github.com/google/go-cmp"))
+}
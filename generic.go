@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Register adds a converter function to the chain, the same as AddConverter, but the function signature is checked at
+// compile time. The converter is called directly, without reflection.
+func Register[From, To any](chain FuncChain, fn func(from From, to *To) error) FuncChain {
+	c, ok := chain.(*funcChain)
+	if !ok {
+		return chain.AddConverter(fn)
+	}
+
+	fromType := reflect.TypeFor[From]()
+	toType := reflect.TypeFor[*To]()
+	if baseType(fromType) == baseType(toType) {
+		panic(fmt.Errorf("converter must convert between different types; got: %s", typeName(baseType(fromType))))
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.assertMutable()
+
+	origin := &convertOrigin{
+		fn: reflect.ValueOf(fn),
+	}
+
+	c.addConvertFunc(fromType, toType, defaultCost, origin, func(from reflect.Value, to reflect.Value) error {
+		return fn(asArg(from, fromType).Interface().(From), asArg(to, toType).Interface().(*To))
+	})
+	return c
+}
+
+// ConvertTo converts the value to a new value of type T using the chain, the same as Convert. If T is a pointer, a new
+// value is allocated to convert to.
+func ConvertTo[T any](chain FuncChain, from any, opts ...ConvertOption) (T, error) {
+	var out T
+	if typ := reflect.TypeFor[T](); isPtr(typ) {
+		to := reflect.New(typ.Elem())
+		err := chain.Convert(from, to.Interface(), opts...)
+		return to.Interface().(T), err
+	}
+	err := chain.Convert(from, &out, opts...)
+	return out, err
+}
@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Register(t *testing.T) {
+	chain := NewFuncChain()
+	Register(chain, func(from t1, to *t2) error {
+		to.Custom2 = from.Custom1
+		return nil
+	})
+	Register(chain, func(from *t2, to *t3) error {
+		to.Custom3 = from.Custom2
+		return nil
+	})
+
+	to := t3{}
+	err := chain.Convert(t1{Name: "name", Custom1: "custom"}, &to)
+	require.NoError(t, err)
+	require.Equal(t, t3{Name: "name", Custom3: "custom"}, to)
+
+	errFailed := fmt.Errorf("failed")
+	Register(chain, func(_ t3, _ *t4) error {
+		return errFailed
+	})
+	err = chain.Convert(t1{}, &t4{})
+	require.ErrorIs(t, err, errFailed)
+}
+
+func Test_RegisterSameType(t *testing.T) {
+	require.Panics(t, func() {
+		Register(NewFuncChain(), func(_ *t1, _ *t1) error {
+			return nil
+		})
+	})
+}
+
+func Test_ConvertTo(t *testing.T) {
+	chain := NewFuncChain(t1ToT2, t2ToT3)
+
+	got, err := ConvertTo[t3](chain, t1{Name: "name", Custom1: "custom"})
+	require.NoError(t, err)
+	require.Equal(t, t3{Name: "name", Custom3: "custom"}, got)
+
+	gotPtr, err := ConvertTo[*t3](chain, &t1{Name: "name", Custom1: "custom"})
+	require.NoError(t, err)
+	require.Equal(t, &t3{Name: "name", Custom3: "custom"}, gotPtr)
+
+	_, err = ConvertTo[t4](chain, t1{})
+	require.Error(t, err)
+}
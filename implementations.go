@@ -0,0 +1,107 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Discriminator chooses the implementation of an interface a value is converted to, see
+// FuncChain.RegisterImplementations. It is called with the source value and returns a value of one of the registered
+// implementation types, e.g. V3Contains{}, or nil to choose the implementation by the registered conversions.
+type Discriminator func(from any) any
+
+// implementations are the types registered as implementing an interface
+type implementations struct {
+	discriminator Discriminator
+	types         []reflect.Type
+}
+
+func (c *funcChain) RegisterImplementations(iface any, discriminator Discriminator, impls ...any) FuncChain {
+	ifaceType := reflect.TypeOf(iface)
+	if ifaceType == nil || !isPtr(ifaceType) || !isInterface(ifaceType.Elem()) {
+		panic(fmt.Errorf("interface must be provided as a nil pointer to the interface, e.g. (*Interface)(nil); got: %v", ifaceType))
+	}
+	ifaceType = ifaceType.Elem()
+
+	var types []reflect.Type
+	for _, impl := range impls {
+		implType := reflect.TypeOf(impl)
+		if implType == nil || !implType.Implements(ifaceType) {
+			panic(fmt.Errorf("%s does not implement %s", nameOf(implType), nameOf(ifaceType)))
+		}
+		if slices.ContainsFunc(types, func(t reflect.Type) bool { return baseType(t) == baseType(implType) }) {
+			panic(fmt.Errorf("implementation %s of %s registered multiple times", nameOf(implType), nameOf(ifaceType)))
+		}
+		types = append(types, implType)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.assertMutable()
+
+	if _, exists := c.implementations[ifaceType]; exists {
+		panic(fmt.Errorf("implementations of %s registered multiple times", nameOf(ifaceType)))
+	}
+	c.implementations[ifaceType] = implementations{
+		discriminator: discriminator,
+		types:         types,
+	}
+
+	// these will be inspected for conversion methods
+	for _, t := range types {
+		c.pending = append(c.pending, baseType(t))
+	}
+	return c
+}
+
+// registeredImplementations returns the implementations registered for the interface, if there are any
+func (c *funcChain) registeredImplementations(iface reflect.Type) (implementations, bool) {
+	if !c.frozen.Load() {
+		c.lock.RLock()
+		defer c.lock.RUnlock()
+	}
+	impls, ok := c.implementations[iface]
+	return impls, ok
+}
+
+// resolveImplementation chooses which of the registered implementations of the interface a value is converted to: the
+// one returned by the discriminator, otherwise the type of the value if it is registered, otherwise the only
+// registered implementation the value's type has a conversion path to
+func (c *conversion) resolveImplementation(fromValue reflect.Value, iface reflect.Type, impls implementations) (reflect.Type, error) {
+	fromType := baseType(fromValue.Type())
+	if impls.discriminator != nil {
+		if chosen := impls.discriminator(fromValue.Interface()); chosen != nil {
+			chosenType := baseType(reflect.TypeOf(chosen))
+			if i := slices.IndexFunc(impls.types, func(t reflect.Type) bool { return baseType(t) == chosenType }); i >= 0 {
+				return impls.types[i], nil
+			}
+			return nil, fmt.Errorf("%w: discriminator returned %s, which is not a registered implementation of %s",
+				ErrNoImplementation, nameOf(reflect.TypeOf(chosen)), nameOf(iface))
+		}
+	}
+
+	if i := slices.IndexFunc(impls.types, func(t reflect.Type) bool { return baseType(t) == fromType }); i >= 0 {
+		return impls.types[i], nil
+	}
+
+	var found []reflect.Type
+	for _, t := range impls.types {
+		c.chain.inspectTypes(fromType, baseType(t))
+		if c.chain.registeredRoute(fromType, baseType(t)) != nil {
+			found = append(found, t)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s has no conversion to any implementation of %s", ErrNoImplementation, nameOf(fromType), nameOf(iface))
+	case 1:
+		return found[0], nil
+	}
+	names := make([]string, len(found))
+	for i, t := range found {
+		names[i] = nameOf(t)
+	}
+	return nil, fmt.Errorf("%w: %s is able to be converted to each of: %s", ErrAmbiguousImplementation, nameOf(fromType), strings.Join(names, ", "))
+}
@@ -0,0 +1,159 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type element interface {
+	isElement()
+}
+
+type elementContains struct {
+	Parent string
+	Child  string
+}
+
+func (elementContains) isElement() {}
+
+type elementAnnotation struct {
+	Author  string
+	Comment string
+}
+
+func (*elementAnnotation) isElement() {}
+
+type elementRelationship struct {
+	Type   string
+	Parent string
+	Child  string
+}
+
+type legacyContains struct {
+	Parent string
+	Child  string
+}
+
+type legacyComment struct {
+	Author string
+	Text   string
+}
+
+func legacyCommentToAnnotation(from legacyComment, to *elementAnnotation) {
+	to.Comment = from.Text
+}
+
+type legacyElements struct {
+	Elements []any
+	Named    map[string]any
+	First    any
+}
+
+type elementDocument struct {
+	Elements []element
+	Named    map[string]element
+	First    element
+}
+
+func Test_RegisterImplementations(t *testing.T) {
+	chain := NewFuncChain(legacyCommentToAnnotation).
+		RegisterImplementations((*element)(nil), nil, elementContains{}, &elementAnnotation{}).
+		AllowImplicit()
+
+	got := elementDocument{}
+	require.NoError(t, chain.Convert(legacyElements{
+		// registered implementations are kept as they are, other types are converted by the registered conversions
+		Elements: []any{legacyComment{Author: "a", Text: "text"}, elementContains{Parent: "p", Child: "c"}, nil},
+		Named:    map[string]any{"note": &legacyComment{Author: "b", Text: "note"}},
+		First:    &elementAnnotation{Author: "c"},
+	}, &got))
+	require.Equal(t, elementDocument{
+		Elements: []element{&elementAnnotation{Author: "a", Comment: "text"}, elementContains{Parent: "p", Child: "c"}, nil},
+		Named:    map[string]element{"note": &elementAnnotation{Author: "b", Comment: "note"}},
+		First:    &elementAnnotation{Author: "c"},
+	}, got)
+
+	// legacyContains has no registered conversion to any implementation
+	err := chain.Convert(legacyElements{First: legacyContains{Parent: "p"}}, &elementDocument{})
+	require.ErrorIs(t, err, ErrNoImplementation)
+	require.ErrorContains(t, err, "converting First from converter.legacyContains to converter.element")
+}
+
+func Test_RegisterImplementationsDiscriminator(t *testing.T) {
+	type from struct {
+		Elements []elementRelationship
+	}
+	discriminator := func(from any) any {
+		switch from.(elementRelationship).Type {
+		case "contains":
+			return elementContains{}
+		case "annotates":
+			return &elementAnnotation{}
+		case "describes":
+			return legacyContains{}
+		}
+		return nil
+	}
+	chain := NewFuncChain().AllowImplicit().
+		RegisterImplementations((*element)(nil), discriminator, elementContains{}, &elementAnnotation{})
+
+	got := elementDocument{}
+	require.NoError(t, chain.Convert(from{Elements: []elementRelationship{
+		{Type: "contains", Parent: "p", Child: "c"},
+		{Type: "annotates"},
+	}}, &got))
+	require.Equal(t, elementDocument{
+		Elements: []element{elementContains{Parent: "p", Child: "c"}, &elementAnnotation{}},
+	}, got)
+
+	err := chain.Convert(from{Elements: []elementRelationship{{Type: "describes"}}}, &elementDocument{})
+	require.ErrorIs(t, err, ErrNoImplementation)
+	require.ErrorContains(t, err, "discriminator returned converter.legacyContains")
+
+	// without a choice from the discriminator, no conversion is registered
+	err = chain.Convert(from{Elements: []elementRelationship{{Type: "other"}}}, &elementDocument{})
+	require.ErrorIs(t, err, ErrNoImplementation)
+	require.ErrorContains(t, err, "converting Elements[0]")
+}
+
+func Test_RegisterImplementationsAmbiguous(t *testing.T) {
+	legacyToContains := func(from legacyContains, to *elementContains) {}
+	legacyToAnnotation := func(from legacyContains, to *elementAnnotation) {}
+	chain := NewFuncChain(legacyToContains, legacyToAnnotation).
+		RegisterImplementations((*element)(nil), nil, elementContains{}, &elementAnnotation{}).
+		AllowImplicit()
+
+	err := chain.Convert(legacyElements{First: legacyContains{}}, &elementDocument{})
+	require.ErrorIs(t, err, ErrAmbiguousImplementation)
+	require.ErrorContains(t, err, "converter.legacyContains is able to be converted to each of: converter.elementContains, *converter.elementAnnotation")
+
+	// the discriminator resolves the ambiguity
+	chain = NewFuncChain(legacyToContains, legacyToAnnotation).
+		RegisterImplementations((*element)(nil), func(any) any { return elementContains{} }, elementContains{}, &elementAnnotation{}).
+		AllowImplicit()
+	got := elementDocument{}
+	require.NoError(t, chain.Convert(legacyElements{First: legacyContains{Parent: "p", Child: "c"}}, &got))
+	require.Equal(t, elementDocument{First: elementContains{Parent: "p", Child: "c"}}, got)
+}
+
+func Test_RegisterImplementationsInvalid(t *testing.T) {
+	require.Panics(t, func() {
+		NewFuncChain().RegisterImplementations(element(nil), nil, elementContains{})
+	})
+	require.Panics(t, func() {
+		// only the pointer implements the interface
+		NewFuncChain().RegisterImplementations((*element)(nil), nil, elementAnnotation{})
+	})
+	require.Panics(t, func() {
+		NewFuncChain().RegisterImplementations((*element)(nil), nil, elementContains{}, &elementContains{})
+	})
+	require.Panics(t, func() {
+		NewFuncChain().
+			RegisterImplementations((*element)(nil), nil, elementContains{}).
+			RegisterImplementations((*element)(nil), nil, &elementAnnotation{})
+	})
+	require.Panics(t, func() {
+		NewFuncChain().Freeze().RegisterImplementations((*element)(nil), nil, elementContains{})
+	})
+}
@@ -0,0 +1,13 @@
+// Package v1 declares types matching those in package v2 by name, to verify conversions registered by
+// AutoPackageConverter.
+package v1
+
+type Package struct {
+	Name    string
+	License License
+	Tags    Tags
+}
+
+type License string
+
+type Tags []string
@@ -0,0 +1,13 @@
+// Package v2 declares types matching those in package v1 by name, to verify conversions registered by
+// AutoPackageConverter.
+package v2
+
+type Package struct {
+	Name    string
+	License License
+	Tags    Tags
+}
+
+type License string
+
+type Tags []string
@@ -0,0 +1,101 @@
+package gentest
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	converter "github.com/anchore/go-struct-converter"
+)
+
+func Test_GeneratedCodeIsCurrent(t *testing.T) {
+	upgrade := converter.Pair[V1, V2]()
+	upgrade.Name = "UpgradeV1"
+
+	src, err := converter.Generate(Chain, converter.GenerateOptions{
+		Package:     "gentest",
+		PackagePath: "github.com/anchore/go-struct-converter/internal/gentest",
+		Pairs: []converter.TypePair{
+			converter.Pair[V1, V3](),
+			converter.Pair[V3, V1](),
+			upgrade,
+		},
+	})
+	require.NoError(t, err)
+
+	existing, err := os.ReadFile("zz_generated.go")
+	require.NoError(t, err)
+	require.Equal(t, string(existing), string(src), "generated code is out of date, run: go generate ./...")
+}
+
+func Test_GeneratedMatchesReflective(t *testing.T) {
+	v1s := []V1{
+		{},
+		{
+			Name:     "name",
+			OldField: "old value",
+			Count:    "12",
+			Tags:     []string{"a", "b"},
+			Files: map[string]FileV1{
+				"a": {Path: "/a", Size: 1},
+				"b": {},
+			},
+			Parent:   &FileV1{Path: "/parent", Size: 42},
+			Labels:   []*string{s("label"), nil, s("")},
+			Checksum: []string{"abc", "def"},
+			Kind:     "file",
+			License:  "MIT",
+			Created:  "2024-01-02T03:04:05Z",
+			Address:  "127.0.0.1",
+			Timeout:  "1m30s",
+			Digest:   "0a1b2c",
+			Notes:    "naïve",
+			Updated:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			Name:     "empty collections",
+			Tags:     []string{},
+			Files:    map[string]FileV1{},
+			Parent:   &FileV1{},
+			Labels:   []*string{},
+			Checksum: []string{},
+		},
+		{
+			Name:  "float count",
+			Count: "3.0",
+		},
+	}
+
+	for _, from := range v1s {
+		t.Run(from.Name, func(t *testing.T) {
+			var expected, got V3
+			require.NoError(t, Chain.Convert(from, &expected))
+			require.NoError(t, ConvertV1ToV3(Chain, from, &got))
+			require.Equal(t, expected, got)
+
+			var expectedV2, gotV2 V2
+			require.NoError(t, Chain.Convert(from, &expectedV2))
+			require.NoError(t, UpgradeV1(Chain, from, &gotV2))
+			require.Equal(t, expectedV2, gotV2)
+
+			var expectedV1, gotV1 V1
+			require.NoError(t, Chain.Convert(expected, &expectedV1))
+			require.NoError(t, ConvertV3ToV1(Chain, got, &gotV1))
+			require.Equal(t, expectedV1, gotV1)
+		})
+	}
+}
+
+func Test_GeneratedErrors(t *testing.T) {
+	for _, from := range []V1{{Count: "not a number"}, {Kind: "unknown"}, {Created: "yesterday"}, {Timeout: "soon"}, {Digest: "xyz"}} {
+		var reflective, generated V3
+		require.Error(t, Chain.Convert(from, &reflective))
+		require.Error(t, ConvertV1ToV3(Chain, from, &generated))
+	}
+}
+
+func s(s string) *string {
+	return &s
+}
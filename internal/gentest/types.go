@@ -0,0 +1,146 @@
+// Package gentest declares versioned types and a chain to verify code generated by convertgen performs the same
+// conversions as the chain does using reflection.
+package gentest
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	converter "github.com/anchore/go-struct-converter"
+)
+
+//go:generate go run ../../cmd/convertgen -chain Chain -pairs V1:V3,V3:V1,V1:V2=UpgradeV1 -o zz_generated.go
+
+// Chain converts between all versions of the document
+var Chain = converter.NewFuncChain(v1ToV2, v2ToV1, v3ToV2, licenseFromString, licenseToString).AddCoercer(parseKind, formatKind)
+
+type V1 struct {
+	Name     string
+	OldField string
+	Count    string
+	Tags     []string
+	Files    map[string]FileV1
+	Parent   *FileV1
+	Labels   []*string
+	Checksum []string
+	Kind     string
+	License  string
+	Created  string
+	Address  string
+	Timeout  string
+	Updated  time.Time
+	Digest   string
+	Notes    string
+}
+
+type FileV1 struct {
+	Path string
+	Size int
+}
+
+type V2 struct {
+	Name     string
+	NewField string
+	Count    int
+	Tags     []string
+	Files    map[string]*FileV2
+	Parent   FileV2
+	Labels   []string
+	Checksum string
+	Kind     string
+	License  License
+	Created  time.Time
+	Address  net.IP
+	Timeout  time.Duration
+	Updated  time.Time
+	Digest   []byte `convert:"encoding=hex"`
+	Notes    []rune
+}
+
+type FileV2 struct {
+	Path string
+	Size string
+}
+
+type V3 struct {
+	Title      string `convert:"name=Name"`
+	FinalField []string
+	Count      *int64
+	Tags       []Tag
+	Files      map[string]FileV2
+	Parent     *FileV2
+	Labels     []*string
+	Checksum   *string
+	Kind       Kind
+	License    *License
+	Created    string
+	Address    *string
+	Timeout    string
+	Updated    *time.Time
+	Digest     string `convert:"encoding=base64"`
+	Notes      []byte
+}
+
+type Tag string
+
+// License is stored as a plain string expression in earlier versions
+type License struct {
+	Expression string
+}
+
+func licenseFromString(from string, to *License) {
+	to.Expression = from
+}
+
+func licenseToString(from License, to *string) {
+	*to = from.Expression
+}
+
+// Kind is an enum which is stored as a string in earlier versions
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindFile
+	KindDirectory
+)
+
+var kindNames = []string{"", "file", "directory"}
+
+func parseKind(s string) (Kind, error) {
+	for i, name := range kindNames {
+		if name == s {
+			return Kind(i), nil
+		}
+	}
+	return KindUnknown, fmt.Errorf("unknown kind: %q", s)
+}
+
+func formatKind(k Kind) (string, error) {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "", fmt.Errorf("unknown kind: %d", k)
+	}
+	return kindNames[k], nil
+}
+
+func v1ToV2(from V1, to *V2) error {
+	to.NewField = from.OldField
+	return nil
+}
+
+func v2ToV1(from V2, to *V1) {
+	to.OldField = from.NewField
+}
+
+func (v *V3) ConvertFrom(chain converter.FuncChain, from V2) error {
+	v.FinalField = []string{from.NewField}
+	return nil
+}
+
+func v3ToV2(from *V3, to *V2) error {
+	if len(from.FinalField) > 0 {
+		to.NewField = from.FinalField[0]
+	}
+	return nil
+}
@@ -0,0 +1,475 @@
+// Code generated by convertgen. DO NOT EDIT.
+
+//go:build !convertgen
+
+package gentest
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"time"
+
+	converter "github.com/anchore/go-struct-converter"
+)
+
+// ConvertV1ToV3 converts gentest.V1 to gentest.V3: V1 -> V2 -> V3
+func ConvertV1ToV3(chain converter.FuncChain, from V1, to *V3) error {
+	var v1 V2
+	if err := convertV1ToV2(chain, from, &v1); err != nil {
+		return err
+	}
+	var v2 V3
+	if err := convertV2ToV3(chain, v1, &v2); err != nil {
+		return err
+	}
+	*to = v2
+	return nil
+}
+
+// ConvertV3ToV1 converts gentest.V3 to gentest.V1: V3 -> V2 -> V1
+func ConvertV3ToV1(chain converter.FuncChain, from V3, to *V1) error {
+	var v1 V2
+	if err := convertV3ToV2(chain, from, &v1); err != nil {
+		return err
+	}
+	var v2 V1
+	if err := convertV2ToV1(chain, v1, &v2); err != nil {
+		return err
+	}
+	*to = v2
+	return nil
+}
+
+// UpgradeV1 converts gentest.V1 to gentest.V2: V1 -> V2
+func UpgradeV1(chain converter.FuncChain, from V1, to *V2) error {
+	var v1 V2
+	if err := convertV1ToV2(chain, from, &v1); err != nil {
+		return err
+	}
+	*to = v1
+	return nil
+}
+
+// convertV1ToV2 converts gentest.V1 to gentest.V2
+func convertV1ToV2(chain converter.FuncChain, from V1, to *V2) error {
+	to.Name = from.Name
+	if from.Count != "" {
+		c1, err := converter.Coerce[int](from.Count)
+		if err != nil {
+			return err
+		}
+		to.Count = c1
+	}
+	if from.Tags != nil {
+		l2 := make([]string, len(from.Tags))
+		for i3, e4 := range from.Tags {
+			l2[i3] = e4
+		}
+		to.Tags = l2
+	}
+	if from.Files != nil {
+		m5 := make(map[string]*FileV2, len(from.Files))
+		for k6, e7 := range from.Files {
+			var s8 FileV2
+			if err := convertFileV1ToFileV2(chain, e7, &s8); err != nil {
+				return err
+			}
+			p9 := s8
+			m5[k6] = &p9
+		}
+		to.Files = m5
+	}
+	if from.Parent != nil && *from.Parent != (FileV1{}) {
+		var s10 FileV2
+		if err := convertFileV1ToFileV2(chain, *from.Parent, &s10); err != nil {
+			return err
+		}
+		to.Parent = s10
+	}
+	if from.Labels != nil {
+		l11 := make([]string, len(from.Labels))
+		for i12, e13 := range from.Labels {
+			if e13 != nil && *e13 != "" {
+				l11[i12] = *e13
+			}
+		}
+		to.Labels = l11
+	}
+	if from.Checksum != nil {
+		if len(from.Checksum) > 0 {
+			to.Checksum = from.Checksum[0]
+		}
+	}
+	to.Kind = from.Kind
+	var v14 License
+	if err := convertStringToLicense(chain, from.License, &v14); err != nil {
+		return err
+	}
+	to.License = v14
+	if from.Created != "" {
+		t15, err := time.Parse(time.RFC3339Nano, from.Created)
+		if err != nil {
+			return err
+		}
+		to.Created = t15
+	}
+	if from.Address != "" {
+		var t16 net.IP
+		if err := t16.UnmarshalText([]byte(from.Address)); err != nil {
+			return err
+		}
+		to.Address = t16
+	}
+	if from.Timeout != "" {
+		t17, err := time.ParseDuration(from.Timeout)
+		if err != nil {
+			return err
+		}
+		to.Timeout = t17
+	}
+	to.Updated = from.Updated
+	if from.Digest != "" {
+		b18, err := hex.DecodeString(from.Digest)
+		if err != nil {
+			return err
+		}
+		to.Digest = b18
+	}
+	if from.Notes != "" {
+		to.Notes = []int32(from.Notes)
+	}
+	if err := v1ToV2(from, to); err != nil {
+		return err
+	}
+	return nil
+}
+
+// convertV2ToV3 converts gentest.V2 to gentest.V3
+func convertV2ToV3(chain converter.FuncChain, from V2, to *V3) error {
+	to.Title = from.Name
+	if from.Count != 0 {
+		c1, err := converter.Coerce[int64](from.Count)
+		if err != nil {
+			return err
+		}
+		p2 := c1
+		to.Count = &p2
+	}
+	if from.Tags != nil {
+		l3 := make([]Tag, len(from.Tags))
+		for i4, e5 := range from.Tags {
+			l3[i4] = Tag(e5)
+		}
+		to.Tags = l3
+	}
+	if from.Files != nil {
+		m6 := make(map[string]FileV2, len(from.Files))
+		for k7, e8 := range from.Files {
+			if e8 != nil && *e8 != (FileV2{}) {
+				var s9 FileV2
+				if err := convertFileV2ToFileV2(chain, *e8, &s9); err != nil {
+					return err
+				}
+				m6[k7] = s9
+			}
+		}
+		to.Files = m6
+	}
+	var s10 FileV2
+	if err := convertFileV2ToFileV2(chain, from.Parent, &s10); err != nil {
+		return err
+	}
+	p11 := s10
+	to.Parent = &p11
+	if from.Labels != nil {
+		l12 := make([]*string, len(from.Labels))
+		for i13, e14 := range from.Labels {
+			p15 := e14
+			l12[i13] = &p15
+		}
+		to.Labels = l12
+	}
+	p16 := from.Checksum
+	to.Checksum = &p16
+	c17, err := parseKind(from.Kind)
+	if err != nil {
+		return err
+	}
+	to.Kind = c17
+	var s18 License
+	if err := convertLicenseToLicense(chain, from.License, &s18); err != nil {
+		return err
+	}
+	p19 := s18
+	to.License = &p19
+	if from.Created != (time.Time{}) {
+		to.Created = from.Created.Format(time.RFC3339Nano)
+	}
+	if from.Address != nil {
+		t20, err := from.Address.MarshalText()
+		if err != nil {
+			return err
+		}
+		p21 := string(t20)
+		to.Address = &p21
+	}
+	if from.Timeout != 0 {
+		to.Timeout = from.Timeout.String()
+	}
+	p22 := from.Updated
+	to.Updated = &p22
+	if from.Digest != nil {
+		to.Digest = base64.StdEncoding.EncodeToString(from.Digest)
+	}
+	if from.Notes != nil {
+		l23 := make([]uint8, len(from.Notes))
+		for i24, e25 := range from.Notes {
+			if e25 != 0 {
+				c26, err := converter.Coerce[uint8](e25)
+				if err != nil {
+					return err
+				}
+				l23[i24] = c26
+			}
+		}
+		to.Notes = l23
+	}
+	if err := to.ConvertFrom(chain, from); err != nil {
+		return err
+	}
+	return nil
+}
+
+// convertV3ToV2 converts gentest.V3 to gentest.V2
+func convertV3ToV2(chain converter.FuncChain, from V3, to *V2) error {
+	to.Name = from.Title
+	if from.Count != nil && *from.Count != 0 {
+		if *from.Count != 0 {
+			c1, err := converter.Coerce[int](*from.Count)
+			if err != nil {
+				return err
+			}
+			to.Count = c1
+		}
+	}
+	if from.Tags != nil {
+		l2 := make([]string, len(from.Tags))
+		for i3, e4 := range from.Tags {
+			l2[i3] = string(e4)
+		}
+		to.Tags = l2
+	}
+	if from.Files != nil {
+		m5 := make(map[string]*FileV2, len(from.Files))
+		for k6, e7 := range from.Files {
+			var s8 FileV2
+			if err := convertFileV2ToFileV2(chain, e7, &s8); err != nil {
+				return err
+			}
+			p9 := s8
+			m5[k6] = &p9
+		}
+		to.Files = m5
+	}
+	if from.Parent != nil && *from.Parent != (FileV2{}) {
+		var s10 FileV2
+		if err := convertFileV2ToFileV2(chain, *from.Parent, &s10); err != nil {
+			return err
+		}
+		to.Parent = s10
+	}
+	if from.Labels != nil {
+		l11 := make([]string, len(from.Labels))
+		for i12, e13 := range from.Labels {
+			if e13 != nil && *e13 != "" {
+				l11[i12] = *e13
+			}
+		}
+		to.Labels = l11
+	}
+	if from.Checksum != nil && *from.Checksum != "" {
+		to.Checksum = *from.Checksum
+	}
+	c14, err := formatKind(from.Kind)
+	if err != nil {
+		return err
+	}
+	to.Kind = c14
+	if from.License != nil && *from.License != (License{}) {
+		var s15 License
+		if err := convertLicenseToLicense(chain, *from.License, &s15); err != nil {
+			return err
+		}
+		to.License = s15
+	}
+	if from.Created != "" {
+		t16, err := time.Parse(time.RFC3339Nano, from.Created)
+		if err != nil {
+			return err
+		}
+		to.Created = t16
+	}
+	if from.Address != nil && *from.Address != "" {
+		if *from.Address != "" {
+			var t17 net.IP
+			if err := t17.UnmarshalText([]byte(*from.Address)); err != nil {
+				return err
+			}
+			to.Address = t17
+		}
+	}
+	if from.Timeout != "" {
+		t18, err := time.ParseDuration(from.Timeout)
+		if err != nil {
+			return err
+		}
+		to.Timeout = t18
+	}
+	if from.Updated != nil && *from.Updated != (time.Time{}) {
+		to.Updated = *from.Updated
+	}
+	if from.Digest != "" {
+		b19, err := base64.StdEncoding.DecodeString(from.Digest)
+		if err != nil {
+			return err
+		}
+		to.Digest = b19
+	}
+	if from.Notes != nil {
+		l20 := make([]int32, len(from.Notes))
+		for i21, e22 := range from.Notes {
+			if e22 != 0 {
+				c23, err := converter.Coerce[int32](e22)
+				if err != nil {
+					return err
+				}
+				l20[i21] = c23
+			}
+		}
+		to.Notes = l20
+	}
+	if err := v3ToV2(&from, to); err != nil {
+		return err
+	}
+	return nil
+}
+
+// convertV2ToV1 converts gentest.V2 to gentest.V1
+func convertV2ToV1(chain converter.FuncChain, from V2, to *V1) error {
+	to.Name = from.Name
+	if from.Count != 0 {
+		to.Count = strconv.FormatInt(int64(from.Count), 10)
+	}
+	if from.Tags != nil {
+		l1 := make([]string, len(from.Tags))
+		for i2, e3 := range from.Tags {
+			l1[i2] = e3
+		}
+		to.Tags = l1
+	}
+	if from.Files != nil {
+		m4 := make(map[string]FileV1, len(from.Files))
+		for k5, e6 := range from.Files {
+			if e6 != nil && *e6 != (FileV2{}) {
+				var s7 FileV1
+				if err := convertFileV2ToFileV1(chain, *e6, &s7); err != nil {
+					return err
+				}
+				m4[k5] = s7
+			}
+		}
+		to.Files = m4
+	}
+	var s8 FileV1
+	if err := convertFileV2ToFileV1(chain, from.Parent, &s8); err != nil {
+		return err
+	}
+	p9 := s8
+	to.Parent = &p9
+	if from.Labels != nil {
+		l10 := make([]*string, len(from.Labels))
+		for i11, e12 := range from.Labels {
+			p13 := e12
+			l10[i11] = &p13
+		}
+		to.Labels = l10
+	}
+	to.Checksum = []string{from.Checksum}
+	to.Kind = from.Kind
+	var v14 string
+	if err := convertLicenseToString(chain, from.License, &v14); err != nil {
+		return err
+	}
+	to.License = v14
+	if from.Created != (time.Time{}) {
+		to.Created = from.Created.Format(time.RFC3339Nano)
+	}
+	if from.Address != nil {
+		t15, err := from.Address.MarshalText()
+		if err != nil {
+			return err
+		}
+		to.Address = string(t15)
+	}
+	if from.Timeout != 0 {
+		to.Timeout = from.Timeout.String()
+	}
+	to.Updated = from.Updated
+	if from.Digest != nil {
+		to.Digest = hex.EncodeToString(from.Digest)
+	}
+	if from.Notes != nil {
+		to.Notes = string(from.Notes)
+	}
+	v2ToV1(from, to)
+	return nil
+}
+
+// convertFileV1ToFileV2 converts gentest.FileV1 to gentest.FileV2
+func convertFileV1ToFileV2(chain converter.FuncChain, from FileV1, to *FileV2) error {
+	to.Path = from.Path
+	if from.Size != 0 {
+		to.Size = strconv.FormatInt(int64(from.Size), 10)
+	}
+	return nil
+}
+
+// convertStringToLicense converts string to gentest.License
+func convertStringToLicense(chain converter.FuncChain, from string, to *License) error {
+	licenseFromString(from, to)
+	return nil
+}
+
+// convertFileV2ToFileV2 converts gentest.FileV2 to gentest.FileV2
+func convertFileV2ToFileV2(chain converter.FuncChain, from FileV2, to *FileV2) error {
+	to.Path = from.Path
+	to.Size = from.Size
+	return nil
+}
+
+// convertLicenseToLicense converts gentest.License to gentest.License
+func convertLicenseToLicense(chain converter.FuncChain, from License, to *License) error {
+	to.Expression = from.Expression
+	return nil
+}
+
+// convertFileV2ToFileV1 converts gentest.FileV2 to gentest.FileV1
+func convertFileV2ToFileV1(chain converter.FuncChain, from FileV2, to *FileV1) error {
+	to.Path = from.Path
+	if from.Size != "" {
+		c1, err := converter.Coerce[int](from.Size)
+		if err != nil {
+			return err
+		}
+		to.Size = c1
+	}
+	return nil
+}
+
+// convertLicenseToString converts gentest.License to string
+func convertLicenseToString(chain converter.FuncChain, from License, to *string) error {
+	licenseToString(from, to)
+	return nil
+}
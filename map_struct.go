@@ -0,0 +1,151 @@
+package converter
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// isStringMap indicates the type is a map with string keys, which are converted to and from struct fields
+func isStringMap(typ reflect.Type) bool {
+	return isMap(typ) && isString(typ.Key())
+}
+
+// getMapStructValue converts a map to a struct, setting each field from the map entry with a key matching the field
+// name, using the same rules and struct tags as fields are matched by
+func (c *conversion) getMapStructValue(fromValue reflect.Value, fromType, baseTargetType reflect.Type) reflect.Value {
+	if fromValue.IsNil() || c.exceedsMaxDepth(fromType, baseTargetType) {
+		return nilValue
+	}
+
+	toValue := reflect.New(baseTargetType).Elem()
+
+	keys := make([]fieldInfo, 0, fromValue.Len())
+	for _, key := range fromValue.MapKeys() {
+		keys = append(keys, fieldInfo{name: key.String()})
+	}
+	// keys are sorted so matching ignoring case is consistent
+	slices.SortFunc(keys, func(a, b fieldInfo) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	used := map[string]bool{}
+	var plan fieldPlan
+	path := c.path
+	for _, to := range keyFields(baseTargetType) {
+		key, ok := findSourceField(to, keys, isEqual)
+		if !ok && c.fieldMatching == FieldMatchCaseInsensitive {
+			key, ok = findSourceField(to, keys, strings.EqualFold)
+		}
+		if !ok {
+			if !to.optional {
+				plan.unmappedTargets = append(plan.unmappedTargets, to)
+			}
+			continue
+		}
+		used[key.name] = true
+
+		keyValue := reflect.ValueOf(key.name).Convert(fromType.Key())
+		c.path = path.key(keyValue)
+		newValue := c.getValue(fromValue.MapIndex(keyValue), to.field.Type)
+		c.path = path
+		if newValue.IsValid() {
+			toValue.FieldByIndex(to.field.Index).Set(newValue)
+		}
+	}
+
+	if c.tracksDropped() {
+		for _, key := range keys {
+			if !used[key.name] {
+				c.path = path.key(reflect.ValueOf(key.name).Convert(fromType.Key()))
+				c.dropped(fromType, baseTargetType, ErrUnmappedField)
+			}
+		}
+		c.path = path
+	}
+
+	if c.stopped() {
+		return nilValue
+	}
+
+	if !c.callHooks(fromValue, fromType, baseTargetType, toValue) {
+		return nilValue
+	}
+
+	if c.unmappedTargets != UnmappedTargetIgnore {
+		c.checkUnmappedTargets(fromType, baseTargetType, toValue, plan)
+	}
+
+	return toValue
+}
+
+// getStructMapValue converts a struct to a map, with an entry for each field keyed by the field name, or the name set
+// by its struct tag. Fields with zero values, unless preserving them, and fields which convert to nothing have no
+// entry. Values converted to an interface type, such as the values of a map[string]any, are kept as they are, including
+// pointers, except structs, see getMapEntryValue.
+func (c *conversion) getStructMapValue(fromValue reflect.Value, fromType, baseTargetType reflect.Type) reflect.Value {
+	if c.exceedsMaxDepth(fromType, baseTargetType) {
+		return nilValue
+	}
+
+	keyType := baseTargetType.Key()
+	elementType := baseTargetType.Elem()
+	toValue := reflect.MakeMap(baseTargetType)
+
+	path := c.path
+	for _, from := range keyFields(fromType) {
+		field := fromValue.FieldByIndex(from.field.Index)
+		if field.IsZero() && !c.preserveZeroValues {
+			continue
+		}
+		c.path = path.field(from.field.Name)
+		var v reflect.Value
+		if isInterface(elementType) && baseTargetType.AssignableTo(elementType) && field.Type().AssignableTo(elementType) {
+			v = c.getMapEntryValue(field, baseTargetType)
+		} else {
+			v = c.getValue(field, elementType)
+		}
+		if v.IsValid() {
+			toValue.SetMapIndex(reflect.ValueOf(from.name).Convert(keyType), v)
+		}
+	}
+	c.path = path
+
+	return toValue
+}
+
+// getMapEntryValue returns the value of an entry in a map with interface values, such as a map[string]any, so the
+// map is able to be used by generic serializers: structs, and pointers to or slices of them, are converted to nested
+// maps of the same type and any other value is kept as it is
+func (c *conversion) getMapEntryValue(value reflect.Value, mapType reflect.Type) reflect.Value {
+	typ := value.Type()
+	switch {
+	case isInterface(typ) && !value.IsNil():
+		return c.getMapEntryValue(value.Elem(), mapType)
+	case isNested(baseType(typ)):
+		if isPtr(typ) && value.IsNil() {
+			return value
+		}
+		return c.getValue(value, mapType)
+	case isSlice(typ) && isNested(baseType(typ.Elem())):
+		if value.IsNil() {
+			return value
+		}
+		out := reflect.MakeSlice(reflect.SliceOf(mapType.Elem()), value.Len(), value.Len())
+		path := c.path
+		for i := range value.Len() {
+			c.path = path.index(i)
+			if v := c.getMapEntryValue(value.Index(i), mapType); v.IsValid() {
+				out.Index(i).Set(v)
+			}
+		}
+		c.path = path
+		return out
+	}
+	return value
+}
+
+// isNested indicates the type is a struct converted to a nested map, rather than a value such as a time.Time
+func isNested(typ reflect.Type) bool {
+	return isStruct(typ) && !isOpaque(typ)
+}
@@ -0,0 +1,118 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mapAddress struct {
+	Street string
+	Zip    int
+}
+
+type mapBase struct {
+	ID string
+}
+
+type mapPerson struct {
+	mapBase
+	Name     string
+	FullName string `convert:"was=fullname"`
+	Age      int
+	Address  *mapAddress
+	Previous []mapAddress
+	Nickname *string
+	Tags     []string
+	Internal string `convert:"-"`
+}
+
+func Test_ConvertMapToStruct(t *testing.T) {
+	chain := NewFuncChain().AllowImplicit()
+
+	from := map[string]any{
+		"ID":       "1",
+		"Name":     "name",
+		"fullname": "full name",
+		"Age":      42.0,
+		"Address": map[string]any{
+			"Street": "main",
+			"Zip":    "12345",
+		},
+		"Tags":     []string{"a"},
+		"Internal": "internal",
+		"Unknown":  "unknown",
+		"Nil":      nil,
+	}
+
+	got := mapPerson{}
+	require.NoError(t, chain.Convert(from, &got))
+	require.Equal(t, mapPerson{
+		mapBase:  mapBase{ID: "1"},
+		Name:     "name",
+		FullName: "full name",
+		Age:      42,
+		Address:  &mapAddress{Street: "main", Zip: 12345},
+		Tags:     []string{"a"},
+	}, got)
+
+	var report Report
+	require.NoError(t, chain.Convert(from, &mapPerson{}, WithReport(&report)))
+	require.Equal(t, []ReportEntry{
+		{Path: `["Internal"]`, Reason: ErrUnmappedField},
+		{Path: `["Nil"]`, Reason: ErrUnmappedField},
+		{Path: `["Unknown"]`, Reason: ErrUnmappedField},
+	}, report.Entries)
+
+	got = mapPerson{}
+	require.NoError(t, chain.Convert(map[string]string{"name": "lower"}, &got, WithFieldMatching(FieldMatchCaseInsensitive)))
+	require.Equal(t, "lower", got.Name)
+
+	err := chain.Convert(map[string]any{"Age": "old"}, &mapPerson{})
+	require.ErrorContains(t, err, `converting ["Age"] from string to int (step 0)`)
+
+	report = Report{}
+	require.NoError(t, chain.Convert(map[string]any{"Name": "name"}, &mapAddress{}, WithUnmappedTargets(UnmappedTargetWarn), WithReport(&report)))
+	require.Equal(t, []ReportEntry{
+		{Path: `["Name"]`, Reason: ErrUnmappedField},
+		{Path: "Street", Reason: ErrUnmappedTarget},
+		{Path: "Zip", Reason: ErrUnmappedTarget},
+	}, report.Entries)
+}
+
+func Test_ConvertStructToMap(t *testing.T) {
+	chain := NewFuncChain().AllowImplicit()
+
+	nickname := "nick"
+	from := mapPerson{
+		mapBase:  mapBase{ID: "1"},
+		Name:     "name",
+		Age:      42,
+		Address:  &mapAddress{Street: "main"},
+		Previous: []mapAddress{{Street: "old", Zip: 1}},
+		Nickname: &nickname,
+		Internal: "internal",
+	}
+
+	// nested structs are converted to nested maps, other values are kept as they are
+	got := map[string]any{}
+	require.NoError(t, chain.Convert(from, &got))
+	require.Equal(t, map[string]any{
+		"ID":       "1",
+		"Name":     "name",
+		"Age":      42,
+		"Address":  map[string]any{"Street": "main"},
+		"Previous": []any{map[string]any{"Street": "old", "Zip": 1}},
+		"Nickname": &nickname,
+	}, got)
+	require.Same(t, &nickname, got["Nickname"])
+
+	strs := map[string]string{}
+	require.NoError(t, chain.Convert(mapAddress{Street: "main", Zip: 12345}, &strs))
+	require.Equal(t, map[string]string{"Street": "main", "Zip": "12345"}, strs)
+
+	// the map is able to be converted back to the struct
+	back := mapAddress{}
+	require.NoError(t, chain.Convert(strs, &back))
+	require.Equal(t, mapAddress{Street: "main", Zip: 12345}, back)
+}
@@ -0,0 +1,177 @@
+package converter
+
+import (
+	"reflect"
+	"time"
+)
+
+// ConvertOption configures a single call to Convert, allowing a shared chain to be used with different rules
+type ConvertOption func(*convertOptions)
+
+// convertOptions are the settings for a single conversion, the defaults are provided by the chain
+type convertOptions struct {
+	preserveZeroValues bool
+	maxDepth           int
+	failFast           bool
+	fieldMatching      FieldMatching
+	hooks              []Hook
+	strict             bool
+	report             *Report
+	unmappedTargets    UnmappedTargetPolicy
+	lenientNumbers     bool
+	stringers          bool
+	timeLayouts        []string
+	timeLocation       *time.Location
+	epochUnit          time.Duration
+	durationUnit       time.Duration
+	byteEncoding       ByteEncoding
+}
+
+// FieldMatching is the strategy used to match source struct fields to target struct fields by name
+type FieldMatching int
+
+const (
+	// FieldMatchExact matches fields with exactly the same name, this is the default
+	FieldMatchExact FieldMatching = iota
+	// FieldMatchCaseInsensitive matches fields with the same name ignoring case, when there is no exact match
+	FieldMatchCaseInsensitive
+)
+
+// UnmappedTargetPolicy is what to do when a target struct field is not set, because there is no source field for it
+// and it is not set by a converter function or hook
+type UnmappedTargetPolicy int
+
+const (
+	// UnmappedTargetIgnore leaves unmapped target fields unset without any notice, this is the default
+	UnmappedTargetIgnore UnmappedTargetPolicy = iota
+	// UnmappedTargetWarn adds an entry to the Report provided by WithReport for each unmapped target field
+	UnmappedTargetWarn
+	// UnmappedTargetError returns an error for each unmapped target field
+	UnmappedTargetError
+)
+
+// Hook is called for each struct value converted, after its fields are mapped and any converter function is called.
+// The path is the location of the value within the value being converted, from is the source value and to is a
+// pointer to the converted value, which may be modified. An error returned is reported as a conversion error.
+type Hook func(path string, from reflect.Value, to reflect.Value) error
+
+// WithZeroValues keeps zero values for this conversion, see FuncChain.PreserveZeroValues
+func WithZeroValues() ConvertOption {
+	return func(o *convertOptions) {
+		o.preserveZeroValues = true
+	}
+}
+
+// WithMaxDepth limits how deeply nested values are converted, which is the number of fields, slice elements and map
+// entries from the value being converted. Values nested deeper are not converted and result in an error. A depth of 0
+// is unlimited, which is the default.
+func WithMaxDepth(depth int) ConvertOption {
+	return func(o *convertOptions) {
+		o.maxDepth = depth
+	}
+}
+
+// WithFailFast stops converting at the first error, rather than converting as much as possible and returning all errors
+func WithFailFast() ConvertOption {
+	return func(o *convertOptions) {
+		o.failFast = true
+	}
+}
+
+// WithFieldMatching sets the strategy used to match struct fields by name, struct tags are considered the same way by
+// each strategy
+func WithFieldMatching(matching FieldMatching) ConvertOption {
+	return func(o *convertOptions) {
+		o.fieldMatching = matching
+	}
+}
+
+// WithHook adds a hook called for each struct value converted, hooks are called in the order they are added
+func WithHook(hook Hook) ConvertOption {
+	return func(o *convertOptions) {
+		o.hooks = append(o.hooks, hook)
+	}
+}
+
+// WithStrict returns an error for any data from the source value which is not converted, see Report for what this
+// includes. The errors are ConversionErrors wrapping the reason, e.g. ErrUnmappedField.
+func WithStrict() ConvertOption {
+	return func(o *convertOptions) {
+		o.strict = true
+	}
+}
+
+// WithReport adds an entry to the report for any data from the source value which is not converted
+func WithReport(report *Report) ConvertOption {
+	return func(o *convertOptions) {
+		o.report = report
+	}
+}
+
+// WithUnmappedTargets sets the policy for target struct fields which are not set by the conversion. A field is unmapped
+// when there is no source field for it and it has the zero value after any converter function and hooks are called.
+// Fields tagged with convert:"optional" are expected to be left unset, so are never reported.
+func WithUnmappedTargets(policy UnmappedTargetPolicy) ConvertOption {
+	return func(o *convertOptions) {
+		o.unmappedTargets = policy
+	}
+}
+
+// WithLenientNumbers converts numbers which overflow the target type, wrapping integers, or lose precision, rounding
+// floats, adding a warning to the Report provided by WithReport rather than returning an ErrOverflow or
+// ErrPrecisionLoss error
+func WithLenientNumbers() ConvertOption {
+	return func(o *convertOptions) {
+		o.lenientNumbers = true
+	}
+}
+
+// WithStringers converts values implementing fmt.Stringer to strings using the String method, when they do not
+// implement encoding.TextMarshaler. This is only used to convert to strings, as there is no way to convert back.
+func WithStringers() ConvertOption {
+	return func(o *convertOptions) {
+		o.stringers = true
+	}
+}
+
+// WithTimeLayouts sets the layouts used to convert a time.Time to and from strings: times are formatted with the first
+// layout, and strings are parsed with the first layout able to parse them. The default is time.RFC3339Nano, which
+// also parses times without fractional seconds.
+func WithTimeLayouts(layouts ...string) ConvertOption {
+	return func(o *convertOptions) {
+		o.timeLayouts = layouts
+	}
+}
+
+// WithTimeLocation sets the time zone times are formatted in, which is otherwise the time zone of the time. This is
+// also the time zone of times parsed with a layout which does not include one, and of times converted from numbers,
+// which are otherwise UTC.
+func WithTimeLocation(location *time.Location) ConvertOption {
+	return func(o *convertOptions) {
+		o.timeLocation = location
+	}
+}
+
+// WithEpochUnit sets the unit of numbers converted to and from a time.Time, which are the number of units since the
+// Unix epoch. The default is time.Second, e.g. time.Millisecond is used for Unix timestamps in milliseconds.
+func WithEpochUnit(unit time.Duration) ConvertOption {
+	return func(o *convertOptions) {
+		o.epochUnit = unit
+	}
+}
+
+// WithDurationUnit sets the unit of numbers converted to and from a time.Duration. The default is time.Nanosecond,
+// which is the value of a time.Duration, e.g. time.Second is used for durations stored as a number of seconds.
+func WithDurationUnit(unit time.Duration) ConvertOption {
+	return func(o *convertOptions) {
+		o.durationUnit = unit
+	}
+}
+
+// WithByteEncoding sets how bytes are stored in strings when converting between them, for fields without an encoding
+// set by a struct tag
+func WithByteEncoding(encoding ByteEncoding) ConvertOption {
+	return func(o *convertOptions) {
+		o.byteEncoding = encoding
+	}
+}
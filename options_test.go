@@ -0,0 +1,301 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithZeroValues(t *testing.T) {
+	type s struct {
+		Enabled *bool
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+	from := s{Enabled: new(bool)}
+
+	got := s{}
+	require.NoError(t, chain.Convert(from, &got))
+	require.Nil(t, got.Enabled)
+
+	got = s{}
+	require.NoError(t, chain.Convert(from, &got, WithZeroValues()))
+	require.Equal(t, from, got)
+
+	// options only apply to a single conversion
+	got = s{}
+	require.NoError(t, chain.Convert(from, &got))
+	require.Nil(t, got.Enabled)
+}
+
+func Test_WithMaxDepth(t *testing.T) {
+	type leaf struct {
+		Value string
+	}
+	type branch struct {
+		Leaf   leaf
+		Leaves []leaf
+	}
+	type root struct {
+		Name   string
+		Branch branch
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+	from := root{Name: "root", Branch: branch{Leaf: leaf{Value: "leaf"}}}
+
+	got := root{}
+	require.NoError(t, chain.Convert(from, &got, WithMaxDepth(3)))
+	require.Equal(t, from, got)
+
+	got = root{}
+	err := chain.Convert(from, &got, WithMaxDepth(1))
+	require.ErrorContains(t, err, "maximum depth of 1 exceeded")
+	var convErr *ConversionError
+	require.ErrorAs(t, err, &convErr)
+	require.Equal(t, "Branch", convErr.Path)
+	require.Equal(t, "root", got.Name)
+}
+
+func Test_WithFailFast(t *testing.T) {
+	type from struct {
+		A string
+		B string
+		C string
+	}
+	type to struct {
+		A int
+		B int
+		C int
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+	value := from{A: "a", B: "2", C: "c"}
+
+	err := chain.Convert(value, &to{})
+	require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+
+	err = chain.Convert(value, &to{}, WithFailFast())
+	require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 1)
+	require.ErrorContains(t, err, "converting A ")
+}
+
+func Test_WithFieldMatching(t *testing.T) {
+	type from struct {
+		UserName string
+		OldValue string
+	}
+	type to struct {
+		Username string
+		NewValue string `convert:"was=oldvalue"`
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+	value := from{UserName: "user", OldValue: "value"}
+
+	got := to{}
+	require.NoError(t, chain.Convert(value, &got))
+	require.Equal(t, to{}, got)
+
+	got = to{}
+	require.NoError(t, chain.Convert(value, &got, WithFieldMatching(FieldMatchCaseInsensitive)))
+	require.Equal(t, to{Username: "user", NewValue: "value"}, got)
+
+	// an exact match is preferred
+	type exact struct {
+		NAME string
+		Name string
+	}
+	got2 := struct{ Name string }{}
+	require.NoError(t, chain.Convert(exact{NAME: "upper", Name: "exact"}, &got2, WithFieldMatching(FieldMatchCaseInsensitive)))
+	require.Equal(t, "exact", got2.Name)
+}
+
+func Test_WithHook(t *testing.T) {
+	type inner struct {
+		Value string
+	}
+	type outer struct {
+		Inner inner
+		Items []inner
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+	from := outer{Inner: inner{Value: "a"}, Items: []inner{{Value: "b"}}}
+
+	var paths []string
+	got := outer{}
+	err := chain.Convert(from, &got,
+		WithHook(func(path string, _ reflect.Value, to reflect.Value) error {
+			paths = append(paths, path)
+			if in, ok := to.Interface().(*inner); ok {
+				in.Value += "!"
+			}
+			return nil
+		}),
+		WithHook(func(_ string, _ reflect.Value, to reflect.Value) error {
+			if in, ok := to.Interface().(*inner); ok {
+				in.Value += "?"
+			}
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Inner", "Items[0]", ""}, paths)
+	require.Equal(t, outer{Inner: inner{Value: "a!?"}, Items: []inner{{Value: "b!?"}}}, got)
+
+	errHook := fmt.Errorf("hook failed")
+	err = chain.Convert(from, &outer{}, WithHook(func(path string, _ reflect.Value, _ reflect.Value) error {
+		if path == "Inner" {
+			return errHook
+		}
+		return nil
+	}))
+	require.ErrorIs(t, err, errHook)
+}
+
+type reportV1 struct {
+	Name     string
+	Removed  string
+	Empty    string
+	Ignored  string `convert:"-"`
+	Labels   map[string]*string
+	Licenses []string
+	Child    *reportV1
+}
+
+type reportV2 struct {
+	Name     string
+	Labels   map[string]string
+	Licenses string
+	Child    *reportV2
+}
+
+func Test_WithReport(t *testing.T) {
+	from := reportV1{
+		Name:     "name",
+		Removed:  "removed",
+		Ignored:  "ignored",
+		Labels:   map[string]*string{"a": ptr("a"), "b": nil},
+		Licenses: []string{"MIT", "Apache-2.0"},
+		Child: &reportV1{
+			Removed:  "child",
+			Licenses: []string{"MIT"},
+		},
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+
+	var report Report
+	got := reportV2{}
+	require.NoError(t, chain.Convert(from, &got, WithReport(&report)))
+	require.Equal(t, "MIT", got.Licenses)
+
+	var paths []string
+	for _, entry := range report.Entries {
+		paths = append(paths, entry.Path)
+	}
+	require.ElementsMatch(t, []string{"Removed", `Labels["b"]`, "Licenses", "Child.Removed"}, paths)
+	for _, entry := range report.Entries {
+		switch entry.Path {
+		case "Removed", "Child.Removed":
+			require.ErrorIs(t, entry.Reason, ErrUnmappedField)
+		case `Labels["b"]`:
+			require.ErrorIs(t, entry.Reason, ErrDroppedEntry)
+		case "Licenses":
+			require.ErrorIs(t, entry.Reason, ErrLossyConversion)
+			require.ErrorContains(t, entry.Reason, "1 of 2 elements dropped")
+		}
+	}
+
+	// fields used by a converter function are not known, so are not reported
+	chain = NewFuncChain(func(_ reportV1, _ *reportV2) {}).AllowImplicit()
+	report = Report{}
+	require.NoError(t, chain.Convert(reportV1{Removed: "removed"}, &reportV2{}, WithReport(&report)))
+	require.Empty(t, report.Entries)
+}
+
+func Test_WithStrict(t *testing.T) {
+	chain := NewFuncChain().AllowImplicit()
+
+	require.NoError(t, chain.Convert(reportV1{Name: "name"}, &reportV2{}, WithStrict()))
+
+	err := chain.Convert(reportV1{Name: "name", Removed: "removed"}, &reportV2{}, WithStrict())
+	require.ErrorIs(t, err, ErrUnmappedField)
+	var convErr *ConversionError
+	require.ErrorAs(t, err, &convErr)
+	require.Equal(t, "Removed", convErr.Path)
+}
+
+func Test_WithUnmappedTargets(t *testing.T) {
+	type embedded struct {
+		Checksum string
+	}
+	type v1 struct {
+		Name     string
+		OldField string
+		Checksum string
+	}
+	type v2 struct {
+		embedded
+		Name     string
+		NewField string
+		Added    string
+		Optional string `convert:"optional"`
+		Ignored  string `convert:"-"`
+	}
+
+	value := v1{Name: "name", OldField: "old"}
+
+	chain := NewFuncChain().AllowImplicit()
+	require.NoError(t, chain.Convert(value, &v2{}))
+
+	var report Report
+	require.NoError(t, chain.Convert(value, &v2{}, WithUnmappedTargets(UnmappedTargetWarn), WithReport(&report)))
+	require.Equal(t, []ReportEntry{
+		{Path: "OldField", Reason: ErrUnmappedField},
+		{Path: "NewField", Reason: ErrUnmappedTarget},
+		{Path: "Added", Reason: ErrUnmappedTarget},
+	}, report.Entries)
+
+	err := chain.Convert(value, &v2{}, WithUnmappedTargets(UnmappedTargetError))
+	require.ErrorIs(t, err, ErrUnmappedTarget)
+	var convErr *ConversionError
+	require.ErrorAs(t, err, &convErr)
+	require.Equal(t, "NewField", convErr.Path)
+
+	// fields set by a converter function are not unmapped
+	chain = NewFuncChain(func(from v1, to *v2) {
+		to.NewField = from.OldField
+		to.Added = "added"
+	}).AllowImplicit()
+	require.NoError(t, chain.Convert(value, &v2{}, WithUnmappedTargets(UnmappedTargetError)))
+}
+
+func Test_WithLenientNumbers(t *testing.T) {
+	type from struct {
+		Size  int64
+		Ratio float64
+	}
+	type to struct {
+		Size  int8
+		Ratio float32
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+	value := from{Size: 300, Ratio: 0.5}
+
+	err := chain.Convert(value, &to{})
+	require.ErrorIs(t, err, ErrOverflow)
+	require.ErrorContains(t, err, "converting Size from int64 to int8 (step 0): number overflows type: 300 overflows int8")
+
+	var report Report
+	got := to{}
+	require.NoError(t, chain.Convert(value, &got, WithLenientNumbers(), WithReport(&report)))
+	require.Equal(t, to{Size: 44, Ratio: 0.5}, got)
+	require.Len(t, report.Entries, 1)
+	require.Equal(t, "Size", report.Entries[0].Path)
+	require.ErrorIs(t, report.Entries[0].Reason, ErrOverflow)
+}
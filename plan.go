@@ -0,0 +1,109 @@
+package converter
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// typePair is a source and target type, used as the key for cached conversion details
+type typePair struct {
+	from reflect.Type
+	to   reflect.Type
+}
+
+// structPlanKey identifies the field mappings between struct types using a field matching strategy
+type structPlanKey struct {
+	typePair
+	matching FieldMatching
+}
+
+// structPlans caches the field mappings between struct types, these only depend on the types themselves and how fields
+// are matched, so are shared by all chains
+var structPlans sync.Map // structPlanKey -> fieldPlan
+
+// structPlan returns the field mappings from one struct type to another, computing them only once per pair of types
+// and field matching strategy
+func structPlan(fromType, toType reflect.Type, matching FieldMatching) fieldPlan {
+	key := structPlanKey{typePair{fromType, toType}, matching}
+	if plan, ok := structPlans.Load(key); ok {
+		return plan.(fieldPlan)
+	}
+	plan, _ := structPlans.LoadOrStore(key, mapFields(fromType, toType, matching))
+	return plan.(fieldPlan)
+}
+
+// structKeys caches the fields of struct types converted to and from map keys
+var structKeys sync.Map // reflect.Type -> []fieldInfo
+
+// keyFields returns the fields of the struct type converted to and from map keys, computing them only once per type
+func keyFields(typ reflect.Type) []fieldInfo {
+	if fields, ok := structKeys.Load(typ); ok {
+		return fields.([]fieldInfo)
+	}
+	fields, _ := structKeys.LoadOrStore(typ, mapKeyFields(typ))
+	return fields.([]fieldInfo)
+}
+
+// route returns the conversion steps from one type to another, the shortest chain is only computed once per pair of
+// types until the chain is modified
+func (c *funcChain) route(fromType, toType reflect.Type) []reflectConvertStep {
+	key := typePair{fromType, toType}
+	if route, ok := c.routes.Load(key); ok {
+		return route.([]reflectConvertStep)
+	}
+
+	// the route is stored while holding the read lock, so any modification, which clears the routes, happens entirely
+	// before or after this
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	route := c.shortestChain(fromType, toType)
+	c.routes.Store(key, route)
+	return route
+}
+
+// registeredRoute returns the registered conversion steps from one type to another, or nil if the types are only able
+// to be converted implicitly
+func (c *funcChain) registeredRoute(fromType, toType reflect.Type) []reflectConvertStep {
+	if fromType == toType {
+		return nil
+	}
+	route := c.route(fromType, toType)
+	// implicit conversions are a single step to the same type
+	if len(route) == 0 || route[len(route)-1].targetType != toType {
+		return nil
+	}
+	return route
+}
+
+// isRoute indicates the registered conversion steps are used to convert between the types. Types other than structs
+// connected only by conversions which do nothing, such as those added by AutoPackageConverter, are converted
+// implicitly instead, which keeps their values.
+func isRoute(route []reflectConvertStep, fromType, toType reflect.Type) bool {
+	if route == nil {
+		return false
+	}
+	if isStruct(fromType) && isStruct(toType) {
+		return true
+	}
+	return slices.ContainsFunc(route, func(step reflectConvertStep) bool {
+		return !step.origin.isNoop()
+	})
+}
+
+// primitiveCoercers caches the functions used to convert between primitive types
+var primitiveCoercers sync.Map // typePair -> primitiveCoercer
+
+// primitiveCoercer converts a value from one primitive type to another
+type primitiveCoercer func(value reflect.Value) (reflect.Value, error)
+
+// getPrimitiveCoercer returns the function to convert values between the primitive types, which is chosen only once
+// per pair of types
+func getPrimitiveCoercer(fromType, toType reflect.Type) primitiveCoercer {
+	key := typePair{fromType, toType}
+	if coercer, ok := primitiveCoercers.Load(key); ok {
+		return coercer.(primitiveCoercer)
+	}
+	coercer, _ := primitiveCoercers.LoadOrStore(key, newPrimitiveCoercer(toType))
+	return coercer.(primitiveCoercer)
+}
@@ -0,0 +1,140 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RouteCacheInvalidation(t *testing.T) {
+	chain := NewFuncChain(t3ToT4, t4ToT5)
+
+	to := t5{}
+	require.NoError(t, chain.Convert(t3{Name: "name"}, &to))
+	require.Equal(t, "FromT4", to.Name)
+
+	// a cheaper route is added after the previous route was cached
+	chain.AddConverter(t3ToT5)
+
+	to = t5{}
+	require.NoError(t, chain.Convert(t3{Name: "name"}, &to))
+	require.Equal(t, "FromT3", to.Name)
+}
+
+func Test_StructPlan(t *testing.T) {
+	type from struct {
+		Name     string
+		OldValue string
+		Removed  string
+		Ignored  string `convert:"-"`
+		hidden   string
+	}
+	type to struct {
+		Name     string
+		NewValue string `convert:"was=OldValue"`
+	}
+
+	fromType := reflect.TypeFor[from]()
+	toType := reflect.TypeFor[to]()
+
+	unmapped := structPlan(fromType, toType, FieldMatchExact).unmappedSources
+	require.Len(t, unmapped, 1)
+	require.Equal(t, "Removed", unmapped[0].field.Name)
+
+	plan := structPlan(fromType, toType, FieldMatchExact).mappings
+	require.Len(t, plan, 2)
+	require.Equal(t, "Name", plan[0].from.field.Name)
+	require.Equal(t, "Name", plan[0].to.field.Name)
+	require.Equal(t, "OldValue", plan[1].from.field.Name)
+	require.Equal(t, "NewValue", plan[1].to.field.Name)
+
+	// the same plan is returned for subsequent calls
+	require.Equal(t, &plan[0], &structPlan(fromType, toType, FieldMatchExact).mappings[0])
+}
+
+type benchFileV1 struct {
+	Path     string
+	Size     string
+	Checksum []string
+}
+
+type benchPackageV1 struct {
+	Name     string
+	Version  string
+	Licenses []string
+	Files    map[string]benchFileV1
+	Metadata *benchFileV1
+}
+
+type benchFileV2 struct {
+	Path     string
+	Size     int
+	Checksum string
+}
+
+type benchPackageV2 struct {
+	Name     string
+	Version  string
+	Licenses []*string
+	Files    map[string]*benchFileV2
+	Metadata benchFileV2
+}
+
+// Benchmark_Convert measures converting a typical document. On linux/amd64, the "cached" and "frozen" cases run in
+// ~18µs with 4208 B/op and 46 allocs/op, and the "cold caches" case in ~45µs with ~23800 B/op and 120 allocs/op.
+func Benchmark_Convert(b *testing.B) {
+	from := benchPackageV1{
+		Name:     "package",
+		Version:  "1.2.3",
+		Licenses: []string{"MIT", "Apache-2.0"},
+		Files: map[string]benchFileV1{
+			"a": {Path: "/a", Size: "123", Checksum: []string{"abc"}},
+			"b": {Path: "/b", Size: "456", Checksum: []string{"def"}},
+		},
+		Metadata: &benchFileV1{Path: "/meta", Size: "789", Checksum: []string{"ghi"}},
+	}
+
+	b.Run("cached", func(b *testing.B) {
+		chain := NewFuncChain().AllowImplicit()
+
+		b.ReportAllocs()
+		for range b.N {
+			to := benchPackageV2{}
+			if err := chain.Convert(from, &to); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// a frozen chain is read without locking
+	b.Run("frozen", func(b *testing.B) {
+		chain := NewFuncChain().AllowImplicit().Freeze()
+
+		b.ReportAllocs()
+		for range b.N {
+			to := benchPackageV2{}
+			if err := chain.Convert(from, &to); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// clears the caches before each conversion, this is the cost of the first conversion between types rather than
+	// the code path from before the caches were added
+	b.Run("cold caches", func(b *testing.B) {
+		chain := NewFuncChain().AllowImplicit().(*funcChain)
+
+		b.ReportAllocs()
+		for range b.N {
+			chain.routes.Clear()
+			structPlans.Clear()
+			primitiveCoercers.Clear()
+
+			to := benchPackageV2{}
+			if err := chain.Convert(from, &to); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
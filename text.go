@@ -0,0 +1,71 @@
+package converter
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+var stringerType = reflect.TypeFor[fmt.Stringer]()
+
+// implements indicates values of the type, or pointers to them, implement the interface
+func implements(typ, iface reflect.Type) bool {
+	return typ.Implements(iface) || reflect.PointerTo(typ).Implements(iface)
+}
+
+// isMarshaledText indicates values of the type are converted to strings by encoding.TextMarshaler
+func isMarshaledText(fromType, toType reflect.Type) bool {
+	return isString(toType) && !isString(fromType) && implements(fromType, textMarshalerType)
+}
+
+// isUnmarshaledText indicates values of the type are converted from strings by encoding.TextUnmarshaler
+func isUnmarshaledText(fromType, toType reflect.Type) bool {
+	return isString(fromType) && !isString(toType) && reflect.PointerTo(toType).Implements(textUnmarshalerType)
+}
+
+// isText indicates values are converted between the types as text, because one is a string and the other implements
+// encoding.TextMarshaler or encoding.TextUnmarshaler, or fmt.Stringer when enabled by WithStringers
+func (c *conversion) isText(fromType, toType reflect.Type) bool {
+	return isMarshaledText(fromType, toType) || isUnmarshaledText(fromType, toType) || c.isStringer(fromType, toType)
+}
+
+// isStringer indicates values of the type are converted to strings by fmt.Stringer, which is only used when enabled
+// and the type does not implement encoding.TextMarshaler
+func (c *conversion) isStringer(fromType, toType reflect.Type) bool {
+	return c.stringers && isString(toType) && !isString(fromType) && implements(fromType, stringerType)
+}
+
+// convertText converts a value to or from a string, see isText
+func (c *conversion) convertText(value reflect.Value, targetType reflect.Type) reflect.Value {
+	typ := value.Type()
+	switch {
+	case isMarshaledText(typ, targetType):
+		out := addressable(value).Interface().(encoding.TextMarshaler)
+		text, err := out.MarshalText()
+		if err != nil {
+			c.err(typ, targetType, err)
+			return nilValue
+		}
+		return reflect.ValueOf(string(text)).Convert(targetType)
+	case isUnmarshaledText(typ, targetType):
+		out := reflect.New(targetType)
+		if err := out.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value.String())); err != nil {
+			c.err(typ, targetType, err)
+			return nilValue
+		}
+		return out.Elem()
+	default:
+		str := addressable(value).Interface().(fmt.Stringer).String()
+		return reflect.ValueOf(str).Convert(targetType)
+	}
+}
+
+// addressable returns a pointer to a copy of the value, which has the methods declared with both value and pointer
+// receivers
+func addressable(value reflect.Value) reflect.Value {
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+	return ptr
+}
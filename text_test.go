@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"net"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConvertText(t *testing.T) {
+	type text struct {
+		Created string
+		Address string
+		Prefix  string
+		Hosts   []string
+	}
+	type typed struct {
+		Created time.Time
+		Address net.IP
+		Prefix  *netip.Prefix
+		Hosts   []netip.Addr
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	prefix := netip.MustParsePrefix("10.0.0.0/8")
+	from := text{
+		Created: "2024-01-02T03:04:05Z",
+		Address: "192.168.0.1",
+		Prefix:  "10.0.0.0/8",
+		Hosts:   []string{"::1", "127.0.0.1"},
+	}
+
+	got := typed{}
+	require.NoError(t, chain.Convert(from, &got))
+	require.Equal(t, typed{
+		Created: created,
+		Address: net.ParseIP("192.168.0.1"),
+		Prefix:  &prefix,
+		Hosts:   []netip.Addr{netip.MustParseAddr("::1"), netip.MustParseAddr("127.0.0.1")},
+	}, got)
+
+	back := text{}
+	require.NoError(t, chain.Convert(got, &back))
+	require.Equal(t, from, back)
+
+	err := chain.Convert(text{Address: "not an address", Hosts: []string{"127.0.0.1", "?"}}, &typed{})
+	require.ErrorContains(t, err, "converting Address from string to net.IP (step 0): invalid IP address: not an address")
+	require.ErrorContains(t, err, "converting Hosts[1] from string to netip.Addr (step 0)")
+
+	// fmt.Stringer is only used when enabled, and only to convert to a string
+	type link struct {
+		URL *url.URL
+	}
+	type linkText struct {
+		URL string
+	}
+	value := link{URL: &url.URL{Scheme: "https", Host: "example.com", Path: "/a"}}
+	require.Error(t, chain.Convert(value, &linkText{}))
+	gotText := linkText{}
+	require.NoError(t, chain.Convert(value, &gotText, WithStringers()))
+	require.Equal(t, linkText{URL: "https://example.com/a"}, gotText)
+	require.Error(t, chain.Convert(gotText, &link{}, WithStringers()))
+}
@@ -0,0 +1,160 @@
+package converter
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+var durationType = reflect.TypeFor[time.Duration]()
+
+// isTime indicates values are converted between the types as times or durations: times to and from strings using the
+// time layouts and to and from numbers of the epoch unit since the Unix epoch, durations to and from strings and, when
+// a duration unit other than a nanosecond is used, to and from numbers of the unit
+func (c *conversion) isTime(fromType, toType reflect.Type) bool {
+	switch {
+	case isTimeText(fromType, toType):
+		return true
+	case fromType == timeType:
+		return isNumber(toType)
+	case toType == timeType:
+		return isNumber(fromType)
+	case fromType == durationType:
+		return isNumber(toType) && c.durationUnit > time.Nanosecond
+	case toType == durationType:
+		return isNumber(fromType) && c.durationUnit > time.Nanosecond
+	}
+	return false
+}
+
+// isTimeText indicates values are converted between a time or duration and a string
+func isTimeText(fromType, toType reflect.Type) bool {
+	switch {
+	case fromType == timeType || fromType == durationType:
+		return isString(toType)
+	case toType == timeType || toType == durationType:
+		return isString(fromType)
+	}
+	return false
+}
+
+// convertTime converts between times or durations and strings or numbers, see isTime
+func (c *conversion) convertTime(value reflect.Value, targetType reflect.Type) (reflect.Value, error) {
+	typ := value.Type()
+	switch {
+	case typ == timeType && isString(targetType):
+		t := value.Interface().(time.Time)
+		if c.timeLocation != nil {
+			t = t.In(c.timeLocation)
+		}
+		return reflect.ValueOf(t.Format(c.layouts()[0])).Convert(targetType), nil
+	case typ == timeType:
+		t := value.Interface().(time.Time)
+		nanos := new(big.Int).Mul(big.NewInt(t.Unix()), big.NewInt(int64(time.Second)))
+		nanos.Add(nanos, big.NewInt(int64(t.Nanosecond())))
+		return numberOfUnits(nanos, c.epochUnitOrDefault(), targetType)
+	case targetType == timeType && isString(typ):
+		return c.parseTime(value.String())
+	case targetType == timeType:
+		nanos, err := unitsAsNanos(value, c.epochUnitOrDefault(), targetType)
+		if nanos == nil {
+			return nilValue, err
+		}
+		sec, nsec := new(big.Int).DivMod(nanos, big.NewInt(int64(time.Second)), new(big.Int))
+		if !sec.IsInt64() {
+			return nilValue, overflowError(formatPrimitive(value), targetType)
+		}
+		return reflect.ValueOf(time.Unix(sec.Int64(), nsec.Int64()).In(c.location())), err
+	case typ == durationType && isString(targetType):
+		return reflect.ValueOf(time.Duration(value.Int()).String()).Convert(targetType), nil
+	case typ == durationType:
+		return numberOfUnits(big.NewInt(value.Int()), c.durationUnit, targetType)
+	case isString(typ):
+		d, err := time.ParseDuration(value.String())
+		if err != nil {
+			return nilValue, err
+		}
+		return reflect.ValueOf(d), nil
+	default:
+		nanos, err := unitsAsNanos(value, c.durationUnit, targetType)
+		if nanos == nil {
+			return nilValue, err
+		}
+		if !nanos.IsInt64() {
+			return nilValue, overflowError(formatPrimitive(value), targetType)
+		}
+		return reflect.ValueOf(time.Duration(nanos.Int64())), err
+	}
+}
+
+// parseTime parses a time using the first of the layouts able to parse it, returning the error from the first layout
+// if none are
+func (c *conversion) parseTime(str string) (reflect.Value, error) {
+	var firstErr error
+	for _, layout := range c.layouts() {
+		t, err := time.ParseInLocation(layout, str, c.location())
+		if err == nil {
+			return reflect.ValueOf(t), nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nilValue, firstErr
+}
+
+// layouts returns the layouts times are formatted and parsed with, see WithTimeLayouts
+func (c *conversion) layouts() []string {
+	if len(c.timeLayouts) == 0 {
+		return []string{time.RFC3339Nano}
+	}
+	return c.timeLayouts
+}
+
+// location returns the location of parsed times without a time zone and times converted from numbers
+func (c *conversion) location() *time.Location {
+	if c.timeLocation == nil {
+		return time.UTC
+	}
+	return c.timeLocation
+}
+
+func (c *conversion) epochUnitOrDefault() time.Duration {
+	if c.epochUnit <= 0 {
+		return time.Second
+	}
+	return c.epochUnit
+}
+
+// numberOfUnits converts a number of nanoseconds to a number of the unit, which is coerced to the number type using the
+// same rules as any other number, so a fraction of the unit is a loss of precision when converted to an integer. A
+// fraction which is not able to be written exactly with 9 decimal places is rounded, which is also a loss of precision.
+func numberOfUnits(nanos *big.Int, unit time.Duration, toType reflect.Type) (reflect.Value, error) {
+	units := new(big.Rat).SetFrac(nanos, big.NewInt(int64(unit)))
+	if units.IsInt() {
+		return getPrimitiveCoercer(stringType, toType)(reflect.ValueOf(units.RatString()))
+	}
+	str := strings.TrimRight(units.FloatString(9), "0")
+	v, err := getPrimitiveCoercer(stringType, toType)(reflect.ValueOf(str))
+	if err == nil && !new(big.Rat).Mul(units, big.NewRat(1e9, 1)).IsInt() {
+		err = precisionError(units.RatString(), toType)
+	}
+	return v, err
+}
+
+// unitsAsNanos converts a number of the unit to a number of nanoseconds, a fraction of a nanosecond is truncated and
+// returned along with an ErrPrecisionLoss error. This returns nil for numbers which are not finite.
+func unitsAsNanos(value reflect.Value, unit time.Duration, toType reflect.Type) (*big.Int, error) {
+	str := formatPrimitive(value)
+	units, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return nil, overflowError(str, toType)
+	}
+	nanos := units.Mul(units, new(big.Rat).SetInt64(int64(unit)))
+	if !nanos.IsInt() {
+		return new(big.Int).Quo(nanos.Num(), nanos.Denom()), precisionError(str, toType)
+	}
+	return nanos.Num(), nil
+}
@@ -0,0 +1,96 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConvertTime(t *testing.T) {
+	type v1 struct {
+		Created  string
+		Modified int64
+		Expires  float64
+		Timeout  string
+		Retry    int
+	}
+	type v2 struct {
+		Created  time.Time
+		Modified *time.Time
+		Expires  time.Time
+		Timeout  time.Duration
+		Retry    time.Duration
+	}
+
+	chain := NewFuncChain().AllowImplicit()
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	modified := time.Unix(1704164645, 0).UTC()
+	from := v1{
+		Created:  "2024-01-02T03:04:05Z",
+		Modified: 1704164645,
+		Expires:  1704164645.5,
+		Timeout:  "1m30s",
+		Retry:    2500000000,
+	}
+	expected := v2{
+		Created:  created,
+		Modified: &modified,
+		Expires:  time.Unix(1704164645, 500000000).UTC(),
+		Timeout:  90 * time.Second,
+		Retry:    2500 * time.Millisecond,
+	}
+
+	got := v2{}
+	require.NoError(t, chain.Convert(from, &got))
+	require.Equal(t, expected, got)
+
+	back := v1{}
+	require.NoError(t, chain.Convert(got, &back))
+	require.Equal(t, from, back)
+
+	// opaque structs are copied
+	same := v2{}
+	require.NoError(t, chain.Convert(got, &same))
+	require.Equal(t, got, same)
+
+	// options change the formats used
+	millis := v1{}
+	require.NoError(t, chain.Convert(got, &millis, WithEpochUnit(time.Millisecond), WithDurationUnit(time.Millisecond)))
+	require.Equal(t, int64(1704164645000), millis.Modified)
+	require.Equal(t, 1704164645500.0, millis.Expires)
+	require.Equal(t, 2500, millis.Retry)
+
+	err := chain.Convert(got, &v1{}, WithDurationUnit(time.Second))
+	require.ErrorIs(t, err, ErrPrecisionLoss)
+	require.ErrorContains(t, err, "converting Retry from time.Duration to int (step 0)")
+
+	var report Report
+	seconds := v1{}
+	require.NoError(t, chain.Convert(got, &seconds, WithDurationUnit(time.Second), WithLenientNumbers(), WithReport(&report)))
+	require.Equal(t, 2, seconds.Retry)
+	require.Len(t, report.Entries, 1)
+
+	// a fraction of the unit is rounded when it has more than 9 decimal places
+	err = chain.Convert(got, &v1{}, WithEpochUnit(time.Hour))
+	require.ErrorIs(t, err, ErrPrecisionLoss)
+	require.ErrorContains(t, err, "converting Expires from time.Time to float64 (step 0)")
+
+	est := time.FixedZone("EST", -5*60*60)
+	layouts := []ConvertOption{WithTimeLayouts("2006-01-02 15:04", time.RFC3339), WithTimeLocation(est)}
+
+	formatted := v1{}
+	require.NoError(t, chain.Convert(got, &formatted, layouts...))
+	require.Equal(t, "2024-01-01 22:04", formatted.Created)
+
+	parsed := v2{}
+	require.NoError(t, chain.Convert(v1{Created: "2024-01-01 22:04"}, &parsed, layouts...))
+	require.True(t, created.Add(-5*time.Second).Equal(parsed.Created))
+	require.NoError(t, chain.Convert(v1{Created: "2024-01-02T03:04:05Z"}, &parsed, layouts...))
+	require.True(t, created.Equal(parsed.Created))
+
+	err = chain.Convert(v1{Created: "yesterday", Timeout: "soon"}, &v2{})
+	require.ErrorContains(t, err, `converting Created from string to time.Time (step 0): parsing time "yesterday"`)
+	require.ErrorContains(t, err, `converting Timeout from string to time.Duration (step 0): time: invalid duration "soon"`)
+}
@@ -0,0 +1,67 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// VersionedChain is a FuncChain for an ordered sequence of versions of a type, see NewVersionedChain
+type VersionedChain interface {
+	FuncChain
+	// Latest returns the type of the last version
+	Latest() reflect.Type
+	// Versions returns the types of the versions, in order from the first to the last
+	Versions() []reflect.Type
+}
+
+// NewVersionedChain returns a chain for the versions of a type, provided in order from the first to the last, e.g.
+// NewVersionedChain(v1.Document{}, v2.Document{}, v3.Document{}). Consecutive versions are converted to and from each
+// other by mapping their fields, the same as with AutoPackageConverter, so converters only need to be added where
+// something changed. Converters added between consecutive versions, including conversion methods, are used in place of
+// the implicit conversions.
+func NewVersionedChain(versions ...any) VersionedChain {
+	c := NewFuncChain().(*funcChain)
+
+	types := make([]reflect.Type, 0, len(versions))
+	for _, version := range versions {
+		typ := reflect.TypeOf(version)
+		if typ == nil || !isStruct(baseType(typ)) {
+			panic(fmt.Errorf("versions must be structs; got: %v", typ))
+		}
+		typ = baseType(typ)
+		if slices.Contains(types, typ) {
+			panic(fmt.Errorf("version %s provided multiple times", nameOf(typ)))
+		}
+		types = append(types, typ)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.versions = types
+	// register in order, which is used to choose between otherwise equal conversion paths
+	for i := 1; i < len(types); i++ {
+		c.addNeighbor(types[i-1], types[i])
+		c.addNeighbor(types[i], types[i-1])
+	}
+	return c
+}
+
+// addNeighbor connects consecutive versions, see NewVersionedChain
+func (c *funcChain) addNeighbor(fromType, toType reflect.Type) {
+	c.addConvertFunc(fromType, toType, defaultCost, &convertOrigin{noop: true, neighbor: true}, func(_ reflect.Value, _ reflect.Value) error {
+		return nil
+	})
+}
+
+func (c *funcChain) Latest() reflect.Type {
+	if len(c.versions) == 0 {
+		return nil
+	}
+	return c.versions[len(c.versions)-1]
+}
+
+func (c *funcChain) Versions() []reflect.Type {
+	return slices.Clone(c.versions)
+}
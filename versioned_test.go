@@ -0,0 +1,92 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type versionedV1 struct {
+	Name string
+	Tags []string
+}
+
+type versionedV2 struct {
+	Name  string
+	Tags  []string
+	Count int
+}
+
+type versionedV3 struct {
+	Title string
+	Tags  []string
+	Count int
+}
+
+func Test_NewVersionedChain(t *testing.T) {
+	chain := NewVersionedChain(versionedV1{}, versionedV2{}, &versionedV3{})
+	// only the renamed field needs converters, these are used instead of the implicit conversions
+	chain.AddConverter(
+		func(from versionedV2, to *versionedV3) { to.Title = from.Name },
+		func(from versionedV3, to *versionedV2) { to.Name = from.Title },
+	)
+
+	require.Equal(t, reflect.TypeFor[versionedV3](), chain.Latest())
+	require.Equal(t, []reflect.Type{
+		reflect.TypeFor[versionedV1](),
+		reflect.TypeFor[versionedV2](),
+		reflect.TypeFor[versionedV3](),
+	}, chain.Versions())
+
+	latest := versionedV3{}
+	require.NoError(t, chain.Convert(versionedV1{Name: "name", Tags: []string{"a"}}, &latest))
+	require.Equal(t, versionedV3{Title: "name", Tags: []string{"a"}}, latest)
+
+	first := versionedV1{}
+	require.NoError(t, chain.Convert(versionedV3{Title: "title", Tags: []string{"b"}, Count: 2}, &first))
+	require.Equal(t, versionedV1{Name: "title", Tags: []string{"b"}}, first)
+
+	// only the implicit conversions are replaced, converters registered twice are still an error
+	require.Panics(t, func() {
+		chain.AddConverter(func(from versionedV2, to *versionedV3) {})
+	})
+}
+
+type versionedMethodV1 struct {
+	Name string
+}
+
+type versionedMethodV2 struct {
+	Name  string
+	Label string
+}
+
+func (v *versionedMethodV2) ConvertFrom(from versionedMethodV1) error {
+	v.Label = "label: " + from.Name
+	return nil
+}
+
+func Test_NewVersionedChainMethods(t *testing.T) {
+	chain := NewVersionedChain(versionedMethodV1{}, versionedMethodV2{})
+
+	// the method is used instead of the implicit conversion
+	got := versionedMethodV2{}
+	require.NoError(t, chain.Convert(versionedMethodV1{Name: "name"}, &got))
+	require.Equal(t, versionedMethodV2{Name: "name", Label: "label: name"}, got)
+
+	// the implicit conversion is still used in the other direction
+	back := versionedMethodV1{}
+	require.NoError(t, chain.Convert(got, &back))
+	require.Equal(t, versionedMethodV1{Name: "name"}, back)
+}
+
+func Test_NewVersionedChainInvalid(t *testing.T) {
+	require.Nil(t, NewVersionedChain().Latest())
+	require.Panics(t, func() {
+		NewVersionedChain(versionedV1{}, "v2")
+	})
+	require.Panics(t, func() {
+		NewVersionedChain(versionedV1{}, versionedV2{}, &versionedV1{})
+	})
+}